@@ -0,0 +1,375 @@
+package logger
+
+import (
+	"fmt"
+	"math/big"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kalyan3104/dme-logger-go/check"
+)
+
+const nilArgDisplay = "<nil>"
+
+var logMarshalerInterface = reflect.TypeOf((*LogMarshaler)(nil)).Elem()
+
+// RenderFunc converts a log argument into its displayed string
+type RenderFunc func(arg interface{}) string
+
+// ByteSliceDisplayFunc converts a byte slice into its displayed string, such as ToHex or ToBase64
+type ByteSliceDisplayFunc func(slice []byte) string
+
+type interfaceRenderer struct {
+	iface  reflect.Type
+	render RenderFunc
+}
+
+type keyByteSliceDisplay struct {
+	keyPattern string
+	display    ByteSliceDisplayFunc
+}
+
+var _ KeyedArgRenderer = (*argRendererRegistry)(nil)
+
+// argRendererRegistry renders the log arguments by their Go types: a renderer registered for the argument's exact
+// type is used first, then the renderer of the most recently registered interface the argument implements, while
+// everything else falls back to "%v" (or to "%+v" for the structs, once the built-in renderers are registered). The
+// byte slices are displayed by the display of their argument key, if any, or else by the registry's byte slice display.
+type argRendererRegistry struct {
+	mut                  sync.RWMutex
+	types                map[reflect.Type]RenderFunc
+	interfaces           []interfaceRenderer
+	keyByteSliceDisplays []keyByteSliceDisplay
+	renderStructFields   bool
+}
+
+// NewArgRendererRegistry creates an argument renderer registry rendering the arguments as the log lines always did:
+// the byte slices as set by SetDisplayByteSlice and everything else with "%v", the LogMarshaler implementations
+// excepted. The more readable built-in renderers are enabled by RegisterBuiltInRenderers.
+func NewArgRendererRegistry() *argRendererRegistry {
+	registry := &argRendererRegistry{
+		types: make(map[reflect.Type]RenderFunc),
+	}
+
+	registry.types[reflect.TypeOf([]byte(nil))] = renderByteSlice
+	registry.interfaces = []interfaceRenderer{
+		{iface: logMarshalerInterface, render: renderLogMarshaler},
+	}
+
+	return registry
+}
+
+// RegisterBuiltInRenderers registers the built-in renderers of the types having no renderer yet: the big numbers
+// with thousands separators, the durations rounded to the millisecond (or to the microsecond below one second) and
+// the byte sizes (ByteSize), while the structs are rendered with their field names ("%+v")
+func (registry *argRendererRegistry) RegisterBuiltInRenderers() {
+	builtInRenderers := map[reflect.Type]RenderFunc{
+		reflect.TypeOf((*big.Int)(nil)):   renderBigInt,
+		reflect.TypeOf((*big.Float)(nil)): renderBigFloat,
+		reflect.TypeOf(time.Duration(0)):  renderDuration,
+		reflect.TypeOf(ByteSize(0)):       renderByteSize,
+	}
+
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	for argType, render := range builtInRenderers {
+		_, exists := registry.types[argType]
+		if !exists {
+			registry.types[argType] = render
+		}
+	}
+	registry.renderStructFields = true
+}
+
+// RegisterType registers the renderer of the arguments having the type of the provided sample value, such as
+// time.Time{} or (*big.Int)(nil)
+func (registry *argRendererRegistry) RegisterType(sample interface{}, render RenderFunc) error {
+	if sample == nil {
+		return ErrNilArgType
+	}
+	if render == nil {
+		return ErrNilRenderFunc
+	}
+
+	registry.mut.Lock()
+	registry.types[reflect.TypeOf(sample)] = render
+	registry.mut.Unlock()
+
+	return nil
+}
+
+// RegisterInterface registers the renderer of the arguments implementing the interface pointed by the provided
+// nil pointer, such as (*fmt.Stringer)(nil). The interfaces registered later take precedence.
+func (registry *argRendererRegistry) RegisterInterface(ifacePointer interface{}, render RenderFunc) error {
+	if ifacePointer == nil {
+		return ErrNilArgType
+	}
+	if render == nil {
+		return ErrNilRenderFunc
+	}
+
+	ptrType := reflect.TypeOf(ifacePointer)
+	if ptrType.Kind() != reflect.Ptr || ptrType.Elem().Kind() != reflect.Interface {
+		return fmt.Errorf("%w: %s", ErrInvalidArgInterface, ptrType)
+	}
+
+	registry.mut.Lock()
+	registry.interfaces = append(registry.interfaces, interfaceRenderer{
+		iface:  ptrType.Elem(),
+		render: render,
+	})
+	registry.mut.Unlock()
+
+	return nil
+}
+
+// SetByteSliceDisplay sets the display of the byte slices rendered by this registry, instead of the default one
+// (see SetDisplayByteSlice)
+func (registry *argRendererRegistry) SetByteSliceDisplay(display ByteSliceDisplayFunc) error {
+	if display == nil {
+		return ErrNilDisplayByteSliceHandler
+	}
+
+	return registry.RegisterType([]byte(nil), func(arg interface{}) string {
+		return display(arg.([]byte))
+	})
+}
+
+// SetKeyByteSliceDisplay sets the display of the byte slices following the arguments named by the provided key
+// pattern (an exact name or a glob such as "*hash", case insensitive), for example ToBase64 for the "signature"
+// arguments. When several key patterns match an argument, the most recently set one applies.
+func (registry *argRendererRegistry) SetKeyByteSliceDisplay(keyPattern string, display ByteSliceDisplayFunc) error {
+	if display == nil {
+		return ErrNilDisplayByteSliceHandler
+	}
+	_, err := path.Match(keyPattern, "")
+	if len(keyPattern) == 0 || err != nil {
+		return fmt.Errorf("%w: '%s'", ErrInvalidKeyPattern, keyPattern)
+	}
+
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	for i, keyDisplay := range registry.keyByteSliceDisplays {
+		if keyDisplay.keyPattern == keyPattern {
+			registry.keyByteSliceDisplays = append(registry.keyByteSliceDisplays[:i], registry.keyByteSliceDisplays[i+1:]...)
+			break
+		}
+	}
+	registry.keyByteSliceDisplays = append(registry.keyByteSliceDisplays, keyByteSliceDisplay{
+		keyPattern: keyPattern,
+		display:    display,
+	})
+
+	return nil
+}
+
+// Render converts the provided argument into its displayed string. A panicking renderer is replaced by "%v".
+func (registry *argRendererRegistry) Render(arg interface{}) string {
+	return registry.RenderKeyed("", arg)
+}
+
+// RenderKeyed converts the provided argument, which follows the provided key, into its displayed string. A panicking
+// renderer is replaced by "%v".
+func (registry *argRendererRegistry) RenderKeyed(key string, arg interface{}) (rendered string) {
+	if arg == nil {
+		return nilArgDisplay
+	}
+
+	render := registry.findRenderFunc(key, arg)
+	defer func() {
+		if r := recover(); r != nil {
+			rendered = fmt.Sprintf("%v", arg)
+		}
+	}()
+
+	return render(arg)
+}
+
+func (registry *argRendererRegistry) findRenderFunc(key string, arg interface{}) RenderFunc {
+	registry.mut.RLock()
+	defer registry.mut.RUnlock()
+
+	_, isByteSlice := arg.([]byte)
+	if isByteSlice && len(key) > 0 {
+		for i := len(registry.keyByteSliceDisplays) - 1; i >= 0; i-- {
+			keyDisplay := registry.keyByteSliceDisplays[i]
+			if isMatchingKeyPattern(key, keyDisplay.keyPattern) {
+				return func(arg interface{}) string {
+					return keyDisplay.display(arg.([]byte))
+				}
+			}
+		}
+	}
+
+	argType := reflect.TypeOf(arg)
+	render, ok := registry.types[argType]
+	if ok {
+		return render
+	}
+
+	for i := len(registry.interfaces) - 1; i >= 0; i-- {
+		if argType.Implements(registry.interfaces[i].iface) {
+			return registry.interfaces[i].render
+		}
+	}
+
+	isStruct := argType.Kind() == reflect.Struct
+	isStructPointer := argType.Kind() == reflect.Ptr && argType.Elem().Kind() == reflect.Struct
+	if registry.renderStructFields && (isStruct || isStructPointer) {
+		return renderStruct
+	}
+
+	return renderDefault
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (registry *argRendererRegistry) IsInterfaceNil() bool {
+	return registry == nil
+}
+
+var _ Formatter = (*argRenderingFormatter)(nil)
+
+// argRenderingFormatter decorates a formatter so that the lines it outputs have their arguments rendered by its own
+// argument renderer instead of the default one (see SetArgRenderer)
+type argRenderingFormatter struct {
+	formatter Formatter
+	renderer  ArgRenderer
+}
+
+// NewArgRenderingFormatter creates a formatter outputting the lines with their arguments rendered by the provided
+// renderer. It must be the outermost formatter of the observer, as the arguments are rendered before formatting.
+func NewArgRenderingFormatter(formatter Formatter, renderer ArgRenderer) (*argRenderingFormatter, error) {
+	if check.IfNil(formatter) {
+		return nil, ErrNilFormatter
+	}
+	if check.IfNil(renderer) {
+		return nil, ErrNilArgRenderer
+	}
+
+	return &argRenderingFormatter{
+		formatter: formatter,
+		renderer:  renderer,
+	}, nil
+}
+
+// Output formats the provided line with the wrapped formatter
+func (arf *argRenderingFormatter) Output(line LogLineHandler) []byte {
+	return arf.formatter.Output(line)
+}
+
+// ArgRenderer returns the renderer of the arguments of the lines output by this formatter
+func (arf *argRenderingFormatter) ArgRenderer() ArgRenderer {
+	return arf.renderer
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (arf *argRenderingFormatter) IsInterfaceNil() bool {
+	return arf == nil
+}
+
+// ByteSize is a number of bytes, rendered with binary units: "1.5 MiB"
+type ByteSize uint64
+
+// String returns the size with the largest binary unit keeping it above 1, with one decimal
+func (size ByteSize) String() string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", uint64(size))
+	}
+
+	divisor := uint64(unit)
+	exponent := 0
+	for value := uint64(size) / unit; value >= unit && exponent < 5; value /= unit {
+		divisor *= unit
+		exponent++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(divisor), "KMGTPE"[exponent])
+}
+
+func renderByteSlice(arg interface{}) string {
+	return DisplayByteSlice(arg.([]byte))
+}
+
+func renderBigInt(arg interface{}) string {
+	value := arg.(*big.Int)
+	if value == nil {
+		return nilArgDisplay
+	}
+
+	return withThousandsSeparators(value.String())
+}
+
+func renderBigFloat(arg interface{}) string {
+	value := arg.(*big.Float)
+	if value == nil {
+		return nilArgDisplay
+	}
+
+	return withThousandsSeparators(value.Text('f', -1))
+}
+
+// withThousandsSeparators inserts commas between the groups of 3 digits of the integer part of the provided number
+func withThousandsSeparators(number string) string {
+	sign := ""
+	if strings.HasPrefix(number, "-") || strings.HasPrefix(number, "+") {
+		sign, number = number[:1], number[1:]
+	}
+
+	integer, fraction := number, ""
+	dot := strings.IndexByte(number, '.')
+	if dot >= 0 {
+		integer, fraction = number[:dot], number[dot:]
+	}
+
+	var builder strings.Builder
+	builder.WriteString(sign)
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteRune(digit)
+	}
+	builder.WriteString(fraction)
+
+	return builder.String()
+}
+
+// renderDuration rounds the durations to 3 decimals of their largest unit: 1.235s, 12.346ms
+func renderDuration(arg interface{}) string {
+	duration := arg.(time.Duration)
+	absolute := duration
+	if absolute < 0 {
+		absolute = -absolute
+	}
+
+	switch {
+	case absolute >= time.Second:
+		return duration.Round(time.Millisecond).String()
+	case absolute >= time.Millisecond:
+		return duration.Round(time.Microsecond).String()
+	default:
+		return duration.String()
+	}
+}
+
+func renderByteSize(arg interface{}) string {
+	return arg.(ByteSize).String()
+}
+
+func renderLogMarshaler(arg interface{}) string {
+	return arg.(LogMarshaler).MarshalLog()
+}
+
+func renderStruct(arg interface{}) string {
+	return fmt.Sprintf("%+v", arg)
+}
+
+func renderDefault(arg interface{}) string {
+	return fmt.Sprintf("%v", arg)
+}
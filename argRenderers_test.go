@@ -0,0 +1,227 @@
+package logger_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kalyan3104/dme-logger-go"
+	"github.com/stretchr/testify/require"
+)
+
+type testEndpoint struct {
+	Host string
+	Port int
+}
+
+type testMarshaler struct{}
+
+func (tm testMarshaler) MarshalLog() string {
+	return "marshaled"
+}
+
+func (tm testMarshaler) String() string {
+	return "stringer"
+}
+
+type testStringer struct {
+	value string
+}
+
+func (ts *testStringer) String() string {
+	return ts.value
+}
+
+func TestArgRendererRegistry_DefaultRenderersShouldKeepTheOutput(t *testing.T) {
+	t.Parallel()
+
+	registry := logger.NewArgRendererRegistry()
+	require.False(t, registry.IsInterfaceNil())
+
+	bigValue, _ := big.NewInt(0).SetString("-1234567890123", 10)
+	require.Equal(t, "-1234567890123", registry.Render(bigValue))
+	require.Equal(t, "1234.5", registry.Render(big.NewFloat(1234.5)))
+	require.Equal(t, "1.23456789s", registry.Render(1234567890*time.Nanosecond))
+	require.Equal(t, "1.5 KiB", registry.Render(logger.ByteSize(1536)))
+
+	require.Equal(t, "failed", registry.Render(errors.New("failed")))
+	require.Equal(t, "marshaled", registry.Render(testMarshaler{}))
+	require.Equal(t, "value", registry.Render(&testStringer{value: "value"}))
+	require.Equal(t, "<nil>", registry.Render((*testStringer)(nil)))
+	require.Equal(t, "{node 8080}", registry.Render(testEndpoint{Host: "node", Port: 8080}))
+	require.Equal(t, "&{node 0}", registry.Render(&testEndpoint{Host: "node"}))
+	require.Equal(t, "<nil>", registry.Render(nil))
+	require.Equal(t, "42", registry.Render(42))
+	require.Equal(t, logger.DisplayByteSlice([]byte("abc")), registry.Render([]byte("abc")))
+}
+
+func TestArgRendererRegistry_BuiltInRenderers(t *testing.T) {
+	t.Parallel()
+
+	registry := logger.NewArgRendererRegistry()
+	err := registry.RegisterType(logger.ByteSize(0), func(arg interface{}) string {
+		return fmt.Sprintf("%d bytes", uint64(arg.(logger.ByteSize)))
+	})
+	require.Nil(t, err)
+	registry.RegisterBuiltInRenderers()
+
+	bigValue, _ := big.NewInt(0).SetString("-1234567890123", 10)
+	require.Equal(t, "-1,234,567,890,123", registry.Render(bigValue))
+	require.Equal(t, "123", registry.Render(big.NewInt(123)))
+	require.Equal(t, "1,234.5", registry.Render(big.NewFloat(1234.5)))
+	require.Equal(t, "<nil>", registry.Render((*big.Int)(nil)))
+
+	require.Equal(t, "1.235s", registry.Render(1234567890*time.Nanosecond))
+	require.Equal(t, "-12.346ms", registry.Render(-12345678*time.Nanosecond))
+	require.Equal(t, "1.5µs", registry.Render(1500*time.Nanosecond))
+
+	// the renderers registered beforehand are kept
+	require.Equal(t, "1536 bytes", registry.Render(logger.ByteSize(1536)))
+
+	require.Equal(t, "marshaled", registry.Render(testMarshaler{}))
+	require.Equal(t, "{Host:node Port:8080}", registry.Render(testEndpoint{Host: "node", Port: 8080}))
+	require.Equal(t, "&{Host:node Port:0}", registry.Render(&testEndpoint{Host: "node"}))
+	require.Equal(t, "42", registry.Render(42))
+
+	otherRegistry := logger.NewArgRendererRegistry()
+	otherRegistry.RegisterBuiltInRenderers()
+	require.Equal(t, "2.0 GiB", otherRegistry.Render(logger.ByteSize(2<<30)))
+}
+
+func TestArgRendererRegistry_RegisterShouldOverrideTheBuiltIns(t *testing.T) {
+	t.Parallel()
+
+	renderNothing := func(arg interface{}) string {
+		return ""
+	}
+	registry := logger.NewArgRendererRegistry()
+	require.Equal(t, logger.ErrNilArgType, registry.RegisterType(nil, renderNothing))
+	require.Equal(t, logger.ErrNilRenderFunc, registry.RegisterType(0, nil))
+	require.Equal(t, logger.ErrNilArgType, registry.RegisterInterface(nil, renderNothing))
+	require.Equal(t, logger.ErrNilRenderFunc, registry.RegisterInterface((*fmt.Stringer)(nil), nil))
+	err := registry.RegisterInterface(testEndpoint{}, renderNothing)
+	require.True(t, errors.Is(err, logger.ErrInvalidArgInterface))
+
+	err = registry.RegisterType(time.Duration(0), func(arg interface{}) string {
+		return fmt.Sprintf("%dns", int64(arg.(time.Duration)))
+	})
+	require.Nil(t, err)
+	require.Equal(t, "1500ns", registry.Render(1500*time.Nanosecond))
+
+	err = registry.RegisterInterface((*fmt.Stringer)(nil), func(arg interface{}) string {
+		return "<" + arg.(fmt.Stringer).String() + ">"
+	})
+	require.Nil(t, err)
+	require.Equal(t, "<stringer>", registry.Render(testMarshaler{}))
+
+	err = registry.RegisterType(testEndpoint{}, func(arg interface{}) string {
+		panic("renderer failure")
+	})
+	require.Nil(t, err)
+	require.Equal(t, "{node 1}", registry.Render(testEndpoint{Host: "node", Port: 1}))
+}
+
+func TestNewArgRenderingFormatter_NilArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	arf, err := logger.NewArgRenderingFormatter(nil, logger.NewArgRendererRegistry())
+	require.Nil(t, arf)
+	require.Equal(t, logger.ErrNilFormatter, err)
+
+	arf, err = logger.NewArgRenderingFormatter(&logger.PlainFormatter{}, nil)
+	require.Nil(t, arf)
+	require.Equal(t, logger.ErrNilArgRenderer, err)
+}
+
+func TestLogOutputSubject_ObserversShouldRenderTheArgumentsWithTheirRenderers(t *testing.T) {
+	t.Parallel()
+
+	registry := logger.NewArgRendererRegistry()
+	_ = registry.RegisterType(logger.ByteSize(0), func(arg interface{}) string {
+		return fmt.Sprintf("%d bytes", uint64(arg.(logger.ByteSize)))
+	})
+	arf, err := logger.NewArgRenderingFormatter(&logger.PlainFormatter{}, registry)
+	require.Nil(t, err)
+	require.False(t, arf.IsInterfaceNil())
+	require.True(t, arf.ArgRenderer() == logger.ArgRenderer(registry))
+
+	defaultBuff := &bytes.Buffer{}
+	customBuff := &bytes.Buffer{}
+	otherCustomBuff := &bytes.Buffer{}
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(defaultBuff, &logger.PlainFormatter{})
+	_ = los.AddObserver(customBuff, arf)
+	_ = los.AddObserver(otherCustomBuff, arf)
+
+	los.Output(&logger.LogLine{
+		Message: "block received",
+		Args:    []interface{}{"size", logger.ByteSize(2048)},
+	})
+	require.Contains(t, defaultBuff.String(), "size = 2.0 KiB")
+	require.Contains(t, customBuff.String(), "size = 2048 bytes")
+	require.Equal(t, customBuff.String(), otherCustomBuff.String())
+}
+
+type funcArgRenderer func(arg interface{}) string
+
+func (far funcArgRenderer) Render(arg interface{}) string {
+	return far(arg)
+}
+
+func (far funcArgRenderer) IsInterfaceNil() bool {
+	return far == nil
+}
+
+func TestLogOutputSubject_UncomparableRenderersShouldNotPanic(t *testing.T) {
+	t.Parallel()
+
+	renderer := funcArgRenderer(func(arg interface{}) string {
+		return "rendered"
+	})
+	arf, _ := logger.NewArgRenderingFormatter(&logger.PlainFormatter{}, renderer)
+	otherArf, _ := logger.NewArgRenderingFormatter(&logger.PlainFormatter{}, renderer)
+
+	buff := &bytes.Buffer{}
+	otherBuff := &bytes.Buffer{}
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(buff, arf)
+	_ = los.AddObserver(otherBuff, otherArf)
+
+	require.NotPanics(t, func() {
+		los.Output(&logger.LogLine{
+			Message: "block received",
+			Args:    []interface{}{"size", 2048},
+		})
+	})
+	require.Contains(t, buff.String(), "rendered = rendered")
+	require.Equal(t, buff.String(), otherBuff.String())
+}
+
+func TestArgRendererRegistry_ByteSliceDisplays(t *testing.T) {
+	t.Parallel()
+
+	registry := logger.NewArgRendererRegistry()
+	require.Equal(t, logger.ErrNilDisplayByteSliceHandler, registry.SetByteSliceDisplay(nil))
+	require.Equal(t, logger.ErrNilDisplayByteSliceHandler, registry.SetKeyByteSliceDisplay("hash", nil))
+	require.True(t, errors.Is(registry.SetKeyByteSliceDisplay("", logger.ToBase64), logger.ErrInvalidKeyPattern))
+	require.True(t, errors.Is(registry.SetKeyByteSliceDisplay("[hash", logger.ToBase64), logger.ErrInvalidKeyPattern))
+
+	data := []byte{0, 0, 1}
+	require.Nil(t, registry.SetByteSliceDisplay(logger.ToHex))
+	require.Nil(t, registry.SetKeyByteSliceDisplay("*hash", logger.ToBase58))
+	require.Nil(t, registry.SetKeyByteSliceDisplay("signature", logger.ToBase64))
+	require.Nil(t, registry.SetKeyByteSliceDisplay("rootHash", logger.ToBase64))
+
+	require.Equal(t, "000001", registry.Render(data))
+	require.Equal(t, "000001", registry.RenderKeyed("address", data))
+	require.Equal(t, "112", registry.RenderKeyed("txHash", data))
+	require.Equal(t, "AAAB", registry.RenderKeyed("ROOTHASH", data))
+	require.Equal(t, "AAAB", registry.RenderKeyed("signature", data))
+	require.Equal(t, "text", registry.RenderKeyed("signature", "text"))
+
+	require.Nil(t, registry.SetKeyByteSliceDisplay("*hash", logger.ToHex))
+	require.Equal(t, "000001", registry.RenderKeyed("rootHash", data))
+}
@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const flushTimeout = 5 * time.Second
+const flushPollInterval = time.Millisecond
+
+// asyncWriter decouples the log output from a (possibly slow) writer: the buffers are queued and written
+// on a dedicated goroutine. When the queue is full, the buffers are dropped so the caller is never blocked.
+type asyncWriter struct {
+	writer      io.Writer
+	chBuffers   chan []byte
+	chClose     chan struct{}
+	chDone      chan struct{}
+	mutClose    sync.Mutex
+	closed      bool
+	numDropped  uint64
+	numInFlight int64
+}
+
+// NewAsyncWriter creates a writer that writes the provided buffers on the wrapped writer on a dedicated goroutine,
+// holding at most queueSize pending buffers
+func NewAsyncWriter(writer io.Writer, queueSize int) (*asyncWriter, error) {
+	if writer == nil {
+		return nil, ErrNilWriter
+	}
+	if queueSize < 1 {
+		return nil, ErrInvalidQueueSize
+	}
+
+	aw := &asyncWriter{
+		writer:    writer,
+		chBuffers: make(chan []byte, queueSize),
+		chClose:   make(chan struct{}),
+		chDone:    make(chan struct{}),
+	}
+	go aw.continuouslyWrite()
+
+	return aw, nil
+}
+
+// Write queues a copy of the provided buffer. Empty buffers are ignored.
+func (aw *asyncWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	buff := make([]byte, len(p))
+	copy(buff, p)
+
+	atomic.AddInt64(&aw.numInFlight, 1)
+	select {
+	case aw.chBuffers <- buff:
+	default:
+		atomic.AddInt64(&aw.numInFlight, -1)
+		atomic.AddUint64(&aw.numDropped, 1)
+	}
+
+	return len(p), nil
+}
+
+func (aw *asyncWriter) continuouslyWrite() {
+	defer close(aw.chDone)
+
+	for {
+		select {
+		case buff := <-aw.chBuffers:
+			aw.write(buff)
+		case <-aw.chClose:
+			aw.drain()
+			return
+		}
+	}
+}
+
+func (aw *asyncWriter) drain() {
+	for {
+		select {
+		case buff := <-aw.chBuffers:
+			aw.write(buff)
+		default:
+			return
+		}
+	}
+}
+
+func (aw *asyncWriter) write(buff []byte) {
+	_, _ = aw.writer.Write(buff)
+	atomic.AddInt64(&aw.numInFlight, -1)
+}
+
+// NumDropped returns the number of buffers dropped because the queue was full
+func (aw *asyncWriter) NumDropped() uint64 {
+	return atomic.LoadUint64(&aw.numDropped)
+}
+
+// IsIdle returns true if all the queued buffers have been written
+func (aw *asyncWriter) IsIdle() bool {
+	return atomic.LoadInt64(&aw.numInFlight) == 0
+}
+
+// Flush waits (at most flushTimeout) for the queued buffers to be written and then flushes the wrapped writer
+func (aw *asyncWriter) Flush() error {
+	deadline := time.Now().Add(flushTimeout)
+	for !aw.IsIdle() && time.Now().Before(deadline) {
+		time.Sleep(flushPollInterval)
+	}
+	if !aw.IsIdle() {
+		return ErrFlushTimeout
+	}
+
+	return flushWriter(aw.writer)
+}
+
+// Close writes the pending buffers and stops the writing goroutine. The wrapped writer is not closed.
+func (aw *asyncWriter) Close() error {
+	aw.mutClose.Lock()
+	defer aw.mutClose.Unlock()
+
+	if aw.closed {
+		return nil
+	}
+	aw.closed = true
+	close(aw.chClose)
+	<-aw.chDone
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (aw *asyncWriter) IsInterfaceNil() bool {
+	return aw == nil
+}
@@ -0,0 +1,89 @@
+package logger_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAsyncWriter_InvalidArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	aw, err := logger.NewAsyncWriter(nil, 1)
+	assert.True(t, aw.IsInterfaceNil())
+	assert.Equal(t, logger.ErrNilWriter, err)
+
+	aw, err = logger.NewAsyncWriter(&mock.WriterStub{}, 0)
+	assert.True(t, aw.IsInterfaceNil())
+	assert.Equal(t, logger.ErrInvalidQueueSize, err)
+}
+
+func TestAsyncWriter_WriteAndCloseShouldWriteAllBuffers(t *testing.T) {
+	t.Parallel()
+
+	mut := sync.Mutex{}
+	output := &bytes.Buffer{}
+	w := &mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			mut.Lock()
+			defer mut.Unlock()
+
+			return output.Write(p)
+		},
+	}
+
+	aw, err := logger.NewAsyncWriter(w, 10)
+	require.Nil(t, err)
+
+	buff := []byte("line1")
+	_, _ = aw.Write(buff)
+	buff[4] = '2'
+	_, _ = aw.Write(buff)
+	_, _ = aw.Write(nil)
+	_ = aw.Close()
+
+	mut.Lock()
+	assert.Equal(t, "line1line2", output.String())
+	mut.Unlock()
+	assert.True(t, aw.IsIdle())
+	assert.Nil(t, aw.Close())
+}
+
+func TestAsyncWriter_FullQueueShouldDropWithoutBlocking(t *testing.T) {
+	t.Parallel()
+
+	chUnblock := make(chan struct{})
+	w := &mock.WriterStub{
+		WriteCalled: func(p []byte) (n int, err error) {
+			<-chUnblock
+			return len(p), nil
+		},
+	}
+
+	aw, err := logger.NewAsyncWriter(w, 1)
+	require.Nil(t, err)
+
+	chDone := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			_, _ = aw.Write([]byte("line"))
+		}
+		close(chDone)
+	}()
+
+	select {
+	case <-chDone:
+	case <-time.After(time.Second):
+		require.Fail(t, "async writer blocked the caller")
+	}
+
+	assert.True(t, aw.NumDropped() >= 8)
+	close(chUnblock)
+	_ = aw.Close()
+}
@@ -0,0 +1,187 @@
+package logger
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"strings"
+
+	"github.com/kalyan3104/dme-logger-go/proto"
+)
+
+// callerSkipFrames is the number of stack frames between runtime.Caller and the caller of the logging method:
+// outputMessage and the logging method itself (Info, Error, LogIfError and so on)
+const callerSkipFrames = 2
+
+var callerPattern = ""
+var callerMatchingStrings []string
+
+// SetCallerPatterns enables the caller capture (file, line, function and package) on the loggers whose names
+// contain any of the provided comma separated matching strings, "*" matching all the loggers. The loggers created
+// afterwards are also checked against them. An empty string disables the caller capture.
+// The caller capture costs a stack walk per output log line, while it costs nothing on the other loggers.
+func SetCallerPatterns(patterns string) error {
+	err := setCallerPatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	onProfileMutated(ProfileChangeSourceSetCallerPatterns)
+	return nil
+}
+
+func setCallerPatterns(patterns string) error {
+	matchingStrings, err := ParseCallerPatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	logMut.Lock()
+	callerPattern = patterns
+	callerMatchingStrings = matchingStrings
+	for name, log := range loggers {
+		log.setCallerCapture(isCallerCaptureMatching(name))
+	}
+	logMut.Unlock()
+
+	return nil
+}
+
+// GetCallerPatterns returns the last set caller patterns
+func GetCallerPatterns() string {
+	logMut.RLock()
+	defer logMut.RUnlock()
+
+	return callerPattern
+}
+
+// ParseCallerPatterns splits the comma separated caller patterns into their matching strings. Errors if any of
+// the matching strings is empty.
+func ParseCallerPatterns(patterns string) ([]string, error) {
+	matchingStrings, ok := splitMatchingStrings(patterns)
+	if !ok {
+		return nil, createErrInvalidCallerPatterns(patterns, "empty matching string")
+	}
+
+	return matchingStrings, nil
+}
+
+// splitMatchingStrings splits comma separated matching strings, returning false if any of them is empty
+func splitMatchingStrings(patterns string) ([]string, bool) {
+	if len(strings.TrimSpace(patterns)) == 0 {
+		return nil, true
+	}
+
+	matchingStrings := strings.Split(patterns, ",")
+	for i, matchingString := range matchingStrings {
+		matchingStrings[i] = strings.TrimSpace(matchingString)
+		if len(matchingStrings[i]) == 0 {
+			return nil, false
+		}
+	}
+
+	return matchingStrings, true
+}
+
+// LoggerWithCallerSkip returns a logger skipping the provided number of additional stack frames when capturing the
+// caller on the log lines of the provided logger. The provided logger itself is returned if it is not a
+// CallerSkipLogger.
+func LoggerWithCallerSkip(log Logger, skip int) Logger {
+	callerSkipLogger, ok := log.(CallerSkipLogger)
+	if !ok {
+		return log
+	}
+
+	return callerSkipLogger.WithCallerSkip(skip)
+}
+
+// isCallerCaptureMatching must be called under logMut
+func isCallerCaptureMatching(loggerName string) bool {
+	return isMatchingAnyPattern(loggerName, callerMatchingStrings)
+}
+
+func isMatchingAnyPattern(loggerName string, matchingStrings []string) bool {
+	for _, matchingString := range matchingStrings {
+		if isMatchingPattern(loggerName, matchingString) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// captureCaller describes the caller of the function found skip frames above captureCaller's caller or, if provided,
+// the frame of the fromPC program counter
+func captureCaller(skip int, fromPC uintptr) *proto.LogCallerMessage {
+	if fromPC != 0 {
+		return callerFromPC(fromPC)
+	}
+
+	pc, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return nil
+	}
+
+	caller := &proto.LogCallerMessage{
+		File: shortFilePath(file),
+		Line: int32(line),
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn != nil {
+		caller.Package, caller.Function = splitFunctionName(fn.Name())
+	}
+
+	return caller
+}
+
+// callerFromPC describes the frame of a program counter returned by runtime.Callers
+func callerFromPC(pc uintptr) *proto.LogCallerMessage {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if len(frame.File) == 0 {
+		return nil
+	}
+
+	caller := &proto.LogCallerMessage{
+		File: shortFilePath(frame.File),
+		Line: int32(frame.Line),
+	}
+	if len(frame.Function) > 0 {
+		caller.Package, caller.Function = splitFunctionName(frame.Function)
+	}
+
+	return caller
+}
+
+// shortFilePath keeps the file name and its parent directory: "process/block/shardblock.go"
+// becomes "block/shardblock.go"
+func shortFilePath(file string) string {
+	dir, fileName := path.Split(file)
+	parent := path.Base(dir)
+	if len(dir) == 0 || parent == "." || parent == "/" {
+		return fileName
+	}
+
+	return parent + "/" + fileName
+}
+
+// splitFunctionName splits a fully qualified function name such as "github.com/org/repo/pkg.(*T).Method"
+// into its package path ("github.com/org/repo/pkg") and function name ("(*T).Method")
+func splitFunctionName(name string) (string, string) {
+	lastSlash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[lastSlash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+
+	dot += lastSlash + 1
+	return name[:dot], name[dot+1:]
+}
+
+func formatCallerElements(caller *proto.LogCallerMessage) string {
+	if caller == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("[%s:%d %s]", caller.File, caller.Line, caller.Function)
+}
@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kalyan3104/dme-logger-go/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func logThroughWrapper(log Logger, message string) {
+	LoggerWithCallerSkip(log, 1).Info(message)
+}
+
+func requireCaller(t *testing.T, line *LogLine, expectedLine int, expectedFunction string) {
+	require.NotNil(t, line.Caller)
+	require.True(t, strings.HasSuffix(line.Caller.File, "/caller_test.go"), line.Caller.File)
+	require.Equal(t, int32(expectedLine), line.Caller.Line)
+	require.Equal(t, expectedFunction, line.Caller.Function)
+	require.Equal(t, "github.com/kalyan3104/dme-logger-go", line.Caller.Package)
+}
+
+func TestCaller_ShouldCaptureOnlyOnTheMatchingLoggers(t *testing.T) {
+	defer func() {
+		_ = SetCallerPatterns("")
+	}()
+
+	capture := &lineCapture{}
+	log := GetOrCreate("caller-test/capture")
+	log.logOutput = capture
+	otherLog := GetOrCreate("caller-test/other")
+	otherLog.logOutput = capture
+
+	log.Info("disabled")
+	require.Nil(t, capture.lines[0].Caller)
+
+	err := SetCallerPatterns("caller-test/capture,caller-test/created")
+	require.Nil(t, err)
+	require.Equal(t, "caller-test/capture,caller-test/created", GetCallerPatterns())
+
+	log.Info("enabled")
+	expectedLine := currentLine() - 1
+	requireCaller(t, capture.lines[1], expectedLine, "TestCaller_ShouldCaptureOnlyOnTheMatchingLoggers")
+
+	log.LogIfError(errors.New("error"))
+	requireCaller(t, capture.lines[2], currentLine()-1, "TestCaller_ShouldCaptureOnlyOnTheMatchingLoggers")
+
+	log.WithTrace("trace", "span").Warn("traced")
+	requireCaller(t, capture.lines[3], currentLine()-1, "TestCaller_ShouldCaptureOnlyOnTheMatchingLoggers")
+
+	logThroughWrapper(log, "wrapped")
+	requireCaller(t, capture.lines[4], currentLine()-1, "TestCaller_ShouldCaptureOnlyOnTheMatchingLoggers")
+
+	otherLog.Info("not matching")
+	require.Nil(t, capture.lines[5].Caller)
+
+	createdLog := GetOrCreate("caller-test/created")
+	require.True(t, createdLog.isCallerCaptureEnabled())
+
+	err = SetCallerPatterns("")
+	require.Nil(t, err)
+	require.False(t, log.isCallerCaptureEnabled())
+	require.False(t, createdLog.isCallerCaptureEnabled())
+}
+
+func TestCaller_ProfileShouldCarryTheCallerPatterns(t *testing.T) {
+	defer func() {
+		_ = SetCallerPatterns("")
+	}()
+
+	profile := GetCurrentProfile()
+	profile.CallerPatterns = "*"
+	err := profile.Apply()
+	require.Nil(t, err)
+	require.Equal(t, "*", GetCurrentProfile().CallerPatterns)
+	require.True(t, GetOrCreate("caller-test/profile").isCallerCaptureEnabled())
+
+	profile.CallerPatterns = "a,,b"
+	err = profile.Apply()
+	require.True(t, errors.Is(err, ErrInvalidProfile))
+	require.Equal(t, "*", GetCallerPatterns())
+}
+
+func TestParseCallerPatterns(t *testing.T) {
+	t.Parallel()
+
+	matchingStrings, err := ParseCallerPatterns("")
+	require.Nil(t, err)
+	require.Empty(t, matchingStrings)
+
+	matchingStrings, err = ParseCallerPatterns("process, p2p")
+	require.Nil(t, err)
+	require.Equal(t, []string{"process", "p2p"}, matchingStrings)
+
+	_, err = ParseCallerPatterns("process,")
+	require.True(t, errors.Is(err, ErrInvalidCallerPatterns))
+}
+
+func TestSplitFunctionName(t *testing.T) {
+	t.Parallel()
+
+	pkg, function := splitFunctionName("github.com/org/repo/pkg.(*T).Method")
+	require.Equal(t, "github.com/org/repo/pkg", pkg)
+	require.Equal(t, "(*T).Method", function)
+
+	pkg, function = splitFunctionName("main.main.func1")
+	require.Equal(t, "main", pkg)
+	require.Equal(t, "main.func1", function)
+
+	require.Equal(t, "block/shard.go", shortFilePath("/src/process/block/shard.go"))
+	require.Equal(t, "shard.go", shortFilePath("shard.go"))
+}
+
+func TestPlainFormatter_ShouldRenderTheCaller(t *testing.T) {
+	t.Parallel()
+
+	line := &LogLineWrapper{}
+	line.Message = "message"
+	line.Caller = &proto.LogCallerMessage{File: "block/shard.go", Line: 42, Function: "(*T).Method", Package: "block"}
+
+	output := string((&PlainFormatter{}).Output(line))
+	require.True(t, strings.Contains(output, "[block/shard.go:42 (*T).Method]"), output)
+
+	output = string((&ConsoleFormatter{}).Output(line))
+	require.True(t, strings.Contains(output, "[block/shard.go:42 (*T).Method]"), output)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/control"
+)
+
+const usage = `Usage: logctl [--socket PATH | --pid PID] COMMAND [ARGS]
+
+The control socket is the one of the given process or else the only one found in the default socket directory.
+
+Commands:
+  set PATTERN [--for DURATION]   set the log level pattern, or override it temporarily when a duration is
+                                 provided, e.g. set "p2p:TRACE" --for 10m
+  get [LOGGER]                   print the log level pattern and the active overrides,
+                                 or the log level of the given logger
+  loggers                        print all the loggers and their log levels
+  correlation on|off             enable or disable the correlation elements
+  logger-name on|off             enable or disable the logger names
+  profile                        print the current logger profile
+  apply FILE                     apply the profile (JSON) found in the given file
+`
+
+type controlClient interface {
+	SetLogLevel(pattern string, duration time.Duration) (string, []logger.LogLevelOverride, error)
+	GetLogLevelPattern() (string, []logger.LogLevelOverride, error)
+	GetLoggerLogLevel(loggerName string) (logger.LogLevel, error)
+	GetLoggers() ([]control.LoggerInfo, error)
+	ApplyProfile(profile logger.Profile) (logger.Profile, error)
+}
+
+func main() {
+	flags := flag.NewFlagSet("logctl", flag.ExitOnError)
+	socketPath := flags.String("socket", "", "path of the control Unix domain socket")
+	pid := flags.Int("pid", 0, "pid of the process whose default control socket is used")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	path, err := resolveSocketPath(*socketPath, *pid)
+	if err == nil {
+		err = run(path, flags.Arg(0), flags.Args()[1:])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "logctl:", err)
+		os.Exit(1)
+	}
+}
+
+// resolveSocketPath returns the provided socket path, or else the default socket path of the provided process, or
+// else the only socket found in the default socket directory
+func resolveSocketPath(socketPath string, pid int) (string, error) {
+	if len(socketPath) > 0 {
+		return socketPath, nil
+	}
+	if pid > 0 {
+		return control.SocketPathOfProcess(pid), nil
+	}
+
+	directory := control.SocketDirectory()
+	paths, err := filepath.Glob(filepath.Join(directory, "*.sock"))
+	if err != nil {
+		return "", err
+	}
+	switch len(paths) {
+	case 0:
+		return "", fmt.Errorf("no control socket found in %s", directory)
+	case 1:
+		return paths[0], nil
+	default:
+		return "", fmt.Errorf("%d control sockets found in %s, select one with --pid or --socket", len(paths), directory)
+	}
+}
+
+func run(socketPath string, command string, args []string) error {
+	c, err := control.NewClient(socketPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	switch command {
+	case "set":
+		return runSet(c, args)
+	case "get":
+		return runGet(c, args)
+	case "loggers":
+		return runLoggers(c)
+	case "correlation":
+		return runToggle(args, c.ToggleCorrelation)
+	case "logger-name":
+		return runToggle(args, c.ToggleLoggerName)
+	case "profile":
+		profile, errGet := c.GetProfile()
+		return printProfile(profile, errGet)
+	case "apply":
+		return runApply(c, args)
+	default:
+		return fmt.Errorf("unknown command '%s'", command)
+	}
+}
+
+func runSet(c controlClient, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing log level pattern")
+	}
+
+	flags := flag.NewFlagSet("set", flag.ContinueOnError)
+	duration := flags.Duration("for", 0, "apply the pattern as a temporary override, for this duration")
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	return printLogLevelPattern(c.SetLogLevel(args[0], *duration))
+}
+
+func runGet(c controlClient, args []string) error {
+	if len(args) == 0 {
+		return printLogLevelPattern(c.GetLogLevelPattern())
+	}
+
+	level, err := c.GetLoggerLogLevel(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(strings.TrimSpace(level.String()))
+	return nil
+}
+
+func runLoggers(c controlClient) error {
+	loggers, err := c.GetLoggers()
+	if err != nil {
+		return err
+	}
+
+	for _, info := range loggers {
+		fmt.Printf("%-40s %s\n", info.Name, info.LogLevel)
+	}
+
+	return nil
+}
+
+func runToggle(args []string, toggle func(enable bool) (logger.Profile, error)) error {
+	if len(args) != 1 || (args[0] != "on" && args[0] != "off") {
+		return fmt.Errorf("expected 'on' or 'off'")
+	}
+
+	return printProfile(toggle(args[0] == "on"))
+}
+
+func runApply(c controlClient, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("missing profile file")
+	}
+
+	data, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	profile, err := logger.UnmarshalProfile(data)
+	if err != nil {
+		return err
+	}
+
+	return printProfile(c.ApplyProfile(profile))
+}
+
+func printLogLevelPattern(pattern string, overrides []logger.LogLevelOverride, err error) error {
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(pattern)
+	for _, override := range overrides {
+		fmt.Printf("override %s, remaining %s\n", override.Pattern, override.Remaining.Round(time.Second))
+	}
+
+	return nil
+}
+
+func printProfile(profile logger.Profile, err error) error {
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(profile.String())
+	return nil
+}
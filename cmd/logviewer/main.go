@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	goLog "log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/stream"
+)
+
+const defaultAddress = "http://127.0.0.1:8080/log"
+
+type viewerFlags struct {
+	address           string
+	level             string
+	withCorrelation   bool
+	withLoggerName    bool
+	marshalizer       string
+	plain             bool
+	file              string
+	reconnectInterval time.Duration
+}
+
+func main() {
+	flags, err := parseFlags(os.Args[1:])
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
+	err = run(flags)
+	if err != nil && err != context.Canceled {
+		goLog.Fatal(err)
+	}
+}
+
+func parseFlags(args []string) (viewerFlags, error) {
+	flags := viewerFlags{}
+	flagSet := flag.NewFlagSet("logviewer", flag.ContinueOnError)
+
+	flagSet.StringVar(&flags.address, "address", defaultAddress, "URL of the node's logs streaming endpoint")
+	flagSet.StringVar(&flags.level, "level", "*:INFO", "comma-separated pairs of (loggerName, logLevel)")
+	flagSet.BoolVar(&flags.withCorrelation, "correlation", false, "include correlation elements in the logs")
+	flagSet.BoolVar(&flags.withLoggerName, "logger-name", false, "include logger name in the logs")
+	flagSet.StringVar(&flags.marshalizer, "marshalizer", stream.MarshalizerJSON,
+		fmt.Sprintf("log lines marshalizer, one of: %s, %s", stream.MarshalizerJSON, stream.MarshalizerGogoProto))
+	flagSet.BoolVar(&flags.plain, "plain", false, "do not use ANSI colors when printing the logs")
+	flagSet.StringVar(&flags.file, "file", "", "optional file where the logs are also written (without colors)")
+	flagSet.DurationVar(&flags.reconnectInterval, "reconnect-interval", 5*time.Second,
+		"time to wait before reconnecting when the connection is lost, 0 disables reconnecting")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return viewerFlags{}, err
+	}
+
+	return flags, nil
+}
+
+func run(flags viewerFlags) error {
+	profile := logger.Profile{
+		LogLevelPatterns: flags.level,
+		WithCorrelation:  flags.withCorrelation,
+		WithLoggerName:   flags.withLoggerName,
+	}
+
+	// the received lines are output by means of the default log output subject, thus the formatters
+	// have to know about the viewer's choices regarding the correlation elements and logger names
+	logger.ToggleCorrelation(profile.WithCorrelation)
+	logger.ToggleLoggerName(profile.WithLoggerName)
+
+	file, err := setupObservers(flags)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer func() {
+			// no line can reach the file once its observer is removed
+			_ = logger.RemoveLogObserver(file)
+			_ = file.Close()
+		}()
+	}
+
+	viewer, err := stream.NewClient(flags.address, flags.marshalizer, profile, logger.GetLogOutputSubject(), flags.reconnectInterval)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		cancel()
+	}()
+
+	return viewer.Run(ctx)
+}
+
+// setupObservers outputs the received lines on the standard output and, if required, in a file which is returned
+// so that it gets closed when the viewer stops
+func setupObservers(flags viewerFlags) (io.WriteCloser, error) {
+	var formatter logger.Formatter = &logger.ConsoleFormatter{}
+	if flags.plain {
+		formatter = &logger.PlainFormatter{}
+	}
+
+	logger.ClearLogObservers()
+	err := logger.AddLogObserver(os.Stdout, formatter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(flags.file) == 0 {
+		return nil, nil
+	}
+
+	file, err := os.OpenFile(flags.file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	err = logger.AddLogObserver(file, &logger.PlainFormatter{})
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return file, nil
+}
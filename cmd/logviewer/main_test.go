@@ -0,0 +1,107 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/stream"
+	"github.com/stretchr/testify/require"
+)
+
+func restoreObservers() func() {
+	writers, formatters := logger.GetLogObservers()
+
+	return func() {
+		_ = logger.ReplaceLogObservers(writers, formatters)
+	}
+}
+
+func TestParseFlags_DefaultValues(t *testing.T) {
+	flags, err := parseFlags(nil)
+	require.Nil(t, err)
+	require.Equal(t, viewerFlags{
+		address:           defaultAddress,
+		level:             "*:INFO",
+		marshalizer:       stream.MarshalizerJSON,
+		reconnectInterval: 5 * time.Second,
+	}, flags)
+}
+
+func TestParseFlags_ProvidedValues(t *testing.T) {
+	flags, err := parseFlags([]string{
+		"--address", "http://node:8080/log",
+		"--level", "*:DEBUG",
+		"--correlation",
+		"--logger-name",
+		"--marshalizer", stream.MarshalizerGogoProto,
+		"--plain",
+		"--file", "node.log",
+		"--reconnect-interval", "0",
+	})
+	require.Nil(t, err)
+	require.Equal(t, viewerFlags{
+		address:         "http://node:8080/log",
+		level:           "*:DEBUG",
+		withCorrelation: true,
+		withLoggerName:  true,
+		marshalizer:     stream.MarshalizerGogoProto,
+		plain:           true,
+		file:            "node.log",
+	}, flags)
+
+	_, err = parseFlags([]string{"--unknown"})
+	require.NotNil(t, err)
+}
+
+func TestSetupObservers_ShouldWriteTheLinesInTheFile(t *testing.T) {
+	defer restoreObservers()()
+
+	path := filepath.Join(t.TempDir(), "viewer.log")
+	file, err := setupObservers(viewerFlags{plain: true, file: path})
+	require.Nil(t, err)
+	require.NotNil(t, file)
+
+	logger.GetLogOutputSubject().Output(&logger.LogLine{
+		LoggerName: "viewer",
+		Message:    "received line",
+		LogLevel:   logger.LogInfo,
+		Timestamp:  time.Now(),
+	})
+	writers, _ := logger.GetLogObservers()
+	require.Equal(t, 2, len(writers))
+	require.Nil(t, file.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.Nil(t, err)
+	require.Contains(t, string(data), "received line")
+}
+
+func TestSetupObservers_WithoutFileShouldReturnNil(t *testing.T) {
+	defer restoreObservers()()
+
+	file, err := setupObservers(viewerFlags{})
+	require.Nil(t, err)
+	require.Nil(t, file)
+
+	writers, _ := logger.GetLogObservers()
+	require.Equal(t, 1, len(writers))
+}
+
+func TestRun_ShouldCloseTheFileOnError(t *testing.T) {
+	defer restoreObservers()()
+
+	path := filepath.Join(t.TempDir(), "viewer.log")
+	err := run(viewerFlags{
+		address:     defaultAddress,
+		level:       "*:INFO",
+		marshalizer: "unknown",
+		file:        path,
+	})
+	require.NotNil(t, err)
+
+	writers, _ := logger.GetLogObservers()
+	require.Equal(t, 1, len(writers))
+}
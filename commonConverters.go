@@ -1,10 +1,14 @@
 package logger
 
 import (
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/kalyan3104/dme-logger-go/proto"
 )
@@ -15,9 +19,53 @@ const correlationElementsFixedLength = 14
 const messageFixedLength = 40
 const ellipsisString = ".."
 
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+const bech32Alphabet = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+const bech32ChecksumLength = 6
+const maxBech32PrefixLength = 83
+
+// DefaultTimestampFormat is the layout used when displaying the log lines' timestamps
+const DefaultTimestampFormat = "2006-01-02 15:04:05.000"
+
+// TimestampOptions holds the options used when displaying the log lines' timestamps
+type TimestampOptions struct {
+	Format string
+	UTC    bool
+}
+
+var mutTimestampOptions = &sync.RWMutex{}
+var timestampOptions = TimestampOptions{Format: DefaultTimestampFormat}
+
+// SetTimestampOptions sets the options used when displaying the log lines' timestamps
+func SetTimestampOptions(options TimestampOptions) error {
+	if len(options.Format) == 0 {
+		return ErrEmptyTimestampFormat
+	}
+
+	mutTimestampOptions.Lock()
+	timestampOptions = options
+	mutTimestampOptions.Unlock()
+
+	return nil
+}
+
+// GetTimestampOptions returns the options used when displaying the log lines' timestamps
+func GetTimestampOptions() TimestampOptions {
+	mutTimestampOptions.RLock()
+	defer mutTimestampOptions.RUnlock()
+
+	return timestampOptions
+}
+
 func displayTime(timestamp int64) string {
+	options := GetTimestampOptions()
+
 	t := time.Unix(0, timestamp)
-	return t.Format("2006-01-02 15:04:05.000")
+	if options.UTC {
+		t = t.UTC()
+	}
+
+	return t.Format(options.Format)
 }
 
 func formatMessage(msg string) string {
@@ -55,7 +103,11 @@ func formatCorrelationElements(correlation proto.LogCorrelationMessage) string {
 	epoch := correlation.GetEpoch()
 	round := correlation.GetRound()
 	subRound := correlation.GetSubRound()
-	formattedElements := fmt.Sprintf("[%s/%d/%d/%s]", shard, epoch, round, subRound)
+	formattedElements := fmt.Sprintf("%s/%d/%d/%s", shard, epoch, round, subRound)
+	for _, element := range correlation.GetElements() {
+		formattedElements += fmt.Sprintf("/%s=%s", element.GetKey(), element.GetValue())
+	}
+	formattedElements = "[" + formattedElements + "]"
 
 	return padRight(formattedElements, correlationElementsFixedLength)
 }
@@ -79,3 +131,154 @@ func ToHexShort(slice []byte) string {
 func ToHex(slice []byte) string {
 	return hex.EncodeToString(slice)
 }
+
+// ToBase64 converts the provided byte slice to its standard (padded) base64 representation
+func ToBase64(slice []byte) string {
+	return base64.StdEncoding.EncodeToString(slice)
+}
+
+// ToBase58 converts the provided byte slice to its base58 representation (bitcoin alphabet), each leading zero
+// byte being encoded as a leading "1"
+func ToBase58(slice []byte) string {
+	numLeadingZeros := 0
+	for numLeadingZeros < len(slice) && slice[numLeadingZeros] == 0 {
+		numLeadingZeros++
+	}
+
+	value := new(big.Int).SetBytes(slice)
+	base := big.NewInt(int64(len(base58Alphabet)))
+	remainder := new(big.Int)
+	encoded := make([]byte, 0, len(slice)*138/100+1)
+	for value.Sign() > 0 {
+		value.DivMod(value, base, remainder)
+		encoded = append(encoded, base58Alphabet[remainder.Int64()])
+	}
+	for i := 0; i < numLeadingZeros; i++ {
+		encoded = append(encoded, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+
+	return string(encoded)
+}
+
+// NewBech32Display creates a byte slice display converting the byte slices to their bech32 representation having
+// the provided human readable prefix, such as "erd" for the addresses. The 90 characters length limit of the bech32
+// addresses is not enforced.
+func NewBech32Display(prefix string) (func(slice []byte) string, error) {
+	if len(prefix) == 0 || len(prefix) > maxBech32PrefixLength {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidBech32Prefix, prefix)
+	}
+	for _, c := range prefix {
+		isPrintable := c >= 33 && c <= 126
+		if !isPrintable || c != unicode.ToLower(c) {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidBech32Prefix, prefix)
+		}
+	}
+
+	return func(slice []byte) string {
+		return toBech32(prefix, slice)
+	}, nil
+}
+
+func toBech32(prefix string, slice []byte) string {
+	data := convertBits(slice, 8, 5)
+	checksum := bech32Checksum(prefix, data)
+
+	var builder strings.Builder
+	builder.Grow(len(prefix) + 1 + len(data) + len(checksum))
+	builder.WriteString(prefix)
+	builder.WriteByte('1')
+	for _, value := range append(data, checksum...) {
+		builder.WriteByte(bech32Alphabet[value])
+	}
+
+	return builder.String()
+}
+
+// convertBits regroups the bits of the provided values from fromBits wide groups to toBits wide groups, padding the
+// last group with zeros
+func convertBits(values []byte, fromBits uint, toBits uint) []byte {
+	accumulator := uint(0)
+	numBits := uint(0)
+	maxValue := uint(1)<<toBits - 1
+	converted := make([]byte, 0, (uint(len(values))*fromBits+toBits-1)/toBits)
+	for _, value := range values {
+		accumulator = accumulator<<fromBits | uint(value)
+		numBits += fromBits
+		for numBits >= toBits {
+			numBits -= toBits
+			converted = append(converted, byte(accumulator>>numBits&maxValue))
+		}
+	}
+	if numBits > 0 {
+		converted = append(converted, byte(accumulator<<(toBits-numBits)&maxValue))
+	}
+
+	return converted
+}
+
+func bech32Checksum(prefix string, data []byte) []byte {
+	values := make([]byte, 0, 2*len(prefix)+1+len(data)+bech32ChecksumLength)
+	for i := 0; i < len(prefix); i++ {
+		values = append(values, prefix[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(prefix); i++ {
+		values = append(values, prefix[i]&31)
+	}
+	values = append(values, data...)
+	values = append(values, make([]byte, bech32ChecksumLength)...)
+
+	polymod := bech32Polymod(values) ^ 1
+	checksum := make([]byte, bech32ChecksumLength)
+	for i := range checksum {
+		checksum[i] = byte(polymod >> uint(5*(bech32ChecksumLength-1-i)) & 31)
+	}
+
+	return checksum
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generators := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	checksum := uint32(1)
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+		for i, generator := range generators {
+			if (top>>uint(i))&1 == 1 {
+				checksum ^= generator
+			}
+		}
+	}
+
+	return checksum
+}
+
+// appendElements appends the provided (formatted) elements to the correlation segment of a log line
+func appendElements(correlation string, elements string) string {
+	if len(elements) == 0 {
+		return correlation
+	}
+	if len(correlation) == 0 {
+		return elements
+	}
+
+	return correlation + " " + elements
+}
+
+// formatDetails returns the formatted trace and caller elements and the formatted stack trace of the provided line,
+// if it carries them (see LogLineDetailsHandler)
+func formatDetails(line LogLineHandler) (string, string) {
+	details, ok := line.(LogLineDetailsHandler)
+	if !ok {
+		return "", ""
+	}
+
+	elements := formatTraceElements(details.GetTraceID(), details.GetSpanID())
+	elements = appendElements(elements, formatCallerElements(details.GetCaller()))
+
+	return elements, formatStack(details.GetStack())
+}
@@ -2,8 +2,10 @@ package logger
 
 import (
 	"encoding/hex"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -55,3 +57,44 @@ func TestToHexShort_SliceLengthBigShouldTrim(t *testing.T) {
 	assert.NotEqual(t, len(hexHash), len(res))
 	assert.True(t, strings.Contains(res, ellipsisString))
 }
+
+func TestSetTimestampOptions(t *testing.T) {
+	defer func() {
+		_ = SetTimestampOptions(TimestampOptions{Format: DefaultTimestampFormat})
+	}()
+
+	err := SetTimestampOptions(TimestampOptions{})
+	assert.Equal(t, ErrEmptyTimestampFormat, err)
+
+	timestamp := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("test", 3600)).UnixNano()
+	err = SetTimestampOptions(TimestampOptions{Format: time.RFC3339, UTC: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "2020-01-02T02:04:05Z", displayTime(timestamp))
+}
+
+func TestToBase64AndToBase58(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "SGVsbG8gV29ybGQh", ToBase64([]byte("Hello World!")))
+	assert.Equal(t, "2NEpo7TZRRrLZSi2U", ToBase58([]byte("Hello World!")))
+	assert.Equal(t, "112", ToBase58([]byte{0, 0, 1}))
+	assert.Equal(t, "", ToBase58(nil))
+}
+
+func TestNewBech32Display(t *testing.T) {
+	t.Parallel()
+
+	for _, prefix := range []string{"", "ERD", strings.Repeat("a", 84), "e d"} {
+		display, err := NewBech32Display(prefix)
+		assert.Nil(t, display)
+		assert.True(t, errors.Is(err, ErrInvalidBech32Prefix))
+	}
+
+	display, err := NewBech32Display("a")
+	assert.Nil(t, err)
+	assert.Equal(t, "a12uel5l", display(nil))
+
+	display, err = NewBech32Display("erd")
+	assert.Nil(t, err)
+	assert.Equal(t, "erd1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq6gq4hu", display(make([]byte, 32)))
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"io"
+	"sync"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+)
+
+var mutApply sync.Mutex
+var appliedConfig *builtConfig
+
+// ownedWriters and ownedFormatters are the observers replaced by the next applied configuration: the observers of the
+// applied configuration or, before the first one, the observers set up by the logger package (the default console
+// observer). The observers added by other components (such as the stream viewers) are kept.
+var ownedWriters []io.Writer
+var ownedFormatters []logger.Formatter
+
+func init() {
+	ownedWriters, ownedFormatters = logger.GetLogObservers()
+}
+
+// loggingState holds the logging setup replaced when applying a configuration, used for rolling back
+type loggingState struct {
+	profile          logger.Profile
+	timestamp        logger.TimestampOptions
+	displayByteSlice func(slice []byte) string
+}
+
+// LoadAndApplyConfig loads the logging configuration file and applies it (see ApplyConfig)
+func LoadAndApplyConfig(path string) error {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	return ApplyConfig(cfg)
+}
+
+// ApplyConfig builds all the components described by the provided configuration (opening the files and connections
+// of the observers) and then applies them. If any part fails, the previous logging setup is restored and the
+// resources opened for the new configuration are released. The resources opened by a previously applied
+// configuration are released once the new one is in place: replacing the observers waits for the lines being output
+// (or flushed), so no line can reach them anymore. Only the observers of the previously applied configuration (or,
+// for the first one, the default console observer) are replaced, the observers added by other components are kept.
+// The profile change observers are not notified, the caller should call logger.NotifyProfileChange if needed.
+func ApplyConfig(cfg *LoggingConfig) error {
+	mutApply.Lock()
+	defer mutApply.Unlock()
+
+	bc, err := buildConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	err = logger.SwapLogObservers(ownedWriters, ownedFormatters, bc.writers, bc.formatters)
+	if err != nil {
+		bc.close()
+		return err
+	}
+
+	previous := captureLoggingState()
+	err = applyBuiltConfig(bc)
+	if err != nil {
+		_ = logger.SwapLogObservers(bc.writers, bc.formatters, ownedWriters, ownedFormatters)
+		restoreLoggingState(previous)
+		bc.close()
+		return err
+	}
+
+	if appliedConfig != nil {
+		appliedConfig.close()
+	}
+	appliedConfig = bc
+	ownedWriters, ownedFormatters = bc.writers, bc.formatters
+
+	return nil
+}
+
+func applyBuiltConfig(bc *builtConfig) error {
+	err := logger.SetTimestampOptions(bc.timestamp)
+	if err != nil {
+		return err
+	}
+
+	err = logger.SetDisplayByteSlice(bc.displayByteSlice)
+	if err != nil {
+		return err
+	}
+
+	return bc.profile.Apply()
+}
+
+func captureLoggingState() loggingState {
+	return loggingState{
+		profile:          logger.GetCurrentProfile(),
+		timestamp:        logger.GetTimestampOptions(),
+		displayByteSlice: logger.GetDisplayByteSlice(),
+	}
+}
+
+func restoreLoggingState(state loggingState) {
+	_ = logger.SetTimestampOptions(state.timestamp)
+	_ = logger.SetDisplayByteSlice(state.displayByteSlice)
+	_ = state.profile.Apply()
+}
@@ -0,0 +1,374 @@
+package config
+
+import (
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/marshal"
+)
+
+const defaultLogLevelPattern = "*:INFO"
+const dialTimeout = 5 * time.Second
+const fileMode = 0644
+
+// builtConfig holds the components built out of a logging configuration, ready to be applied
+type builtConfig struct {
+	profile          logger.Profile
+	writers          []io.Writer
+	formatters       []logger.Formatter
+	timestamp        logger.TimestampOptions
+	displayByteSlice func(slice []byte) string
+	closers          []io.Closer
+}
+
+// close releases the resources (files, connections, async writers) opened while building, in reverse order
+func (bc *builtConfig) close() {
+	for i := len(bc.closers) - 1; i >= 0; i-- {
+		_ = bc.closers[i].Close()
+	}
+}
+
+func buildConfig(cfg *LoggingConfig) (*builtConfig, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	bc := &builtConfig{}
+
+	var err error
+	bc.profile, err = buildProfile(cfg.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	bc.timestamp = logger.TimestampOptions{
+		Format: cfg.Timestamp.Format,
+		UTC:    cfg.Timestamp.UTC,
+	}
+	if len(bc.timestamp.Format) == 0 {
+		bc.timestamp.Format = logger.DefaultTimestampFormat
+	}
+
+	bc.displayByteSlice, err = buildDisplayByteSlice(cfg.ByteSliceDisplay)
+	if err != nil {
+		return nil, err
+	}
+
+	observers := cfg.Observers
+	if len(observers) == 0 {
+		observers = []ObserverConfig{{Type: ObserverStdout}}
+	}
+	for i, observer := range observers {
+		err = bc.addObserver(observer)
+		if err != nil {
+			bc.close()
+			return nil, createErrObserver(i, err)
+		}
+	}
+
+	return bc, nil
+}
+
+func buildProfile(cfg ProfileConfig) (logger.Profile, error) {
+	profile := logger.Profile{
+		LogLevelPatterns:   cfg.LogLevelPatterns,
+		WithCorrelation:    cfg.WithCorrelation,
+		WithLoggerName:     cfg.WithLoggerName,
+		CorrelationFilter:  cfg.CorrelationFilter,
+		CallerPatterns:     cfg.CallerPatterns,
+		StackTracePatterns: cfg.StackTracePatterns,
+	}
+	if len(profile.LogLevelPatterns) == 0 {
+		profile.LogLevelPatterns = defaultLogLevelPattern
+	}
+
+	_, _, err := logger.ParseLogLevelAndMatchingString(profile.LogLevelPatterns)
+	if err != nil {
+		return logger.Profile{}, err
+	}
+	if len(profile.CorrelationFilter) > 0 {
+		_, err = logger.NewCorrelationFilter(profile.CorrelationFilter)
+		if err != nil {
+			return logger.Profile{}, err
+		}
+	}
+
+	_, err = logger.ParseCallerPatterns(profile.CallerPatterns)
+	if err != nil {
+		return logger.Profile{}, err
+	}
+	_, err = logger.ParseStackTracePatterns(profile.StackTracePatterns)
+	if err != nil {
+		return logger.Profile{}, err
+	}
+
+	for _, rule := range cfg.RedactionRules {
+		profile.RedactionRules = append(profile.RedactionRules, logger.RedactionRule{
+			Key:       rule.Key,
+			Value:     rule.Value,
+			Sensitive: rule.Sensitive,
+			Strategy:  rule.Strategy,
+		})
+	}
+
+	err = buildLineSuppression(cfg, &profile)
+	if err != nil {
+		return logger.Profile{}, err
+	}
+
+	for _, override := range cfg.LogLevelOverrides {
+		_, _, err = logger.ParseLogLevelAndMatchingString(override.Pattern)
+		if err != nil {
+			return logger.Profile{}, err
+		}
+
+		duration, errParse := time.ParseDuration(override.Duration)
+		if errParse != nil || duration <= 0 {
+			return logger.Profile{}, createErrUnknownValue(ErrInvalidOverrideDuration, override.Duration)
+		}
+
+		profile.LogLevelOverrides = append(profile.LogLevelOverrides, logger.LogLevelOverride{
+			Pattern:   override.Pattern,
+			Remaining: duration,
+		})
+	}
+
+	return profile, nil
+}
+
+func buildLineSuppression(cfg ProfileConfig, profile *logger.Profile) error {
+	for _, rateLimit := range cfg.RateLimits {
+		profile.RateLimits = append(profile.RateLimits, logger.RateLimit{
+			Pattern:        rateLimit.Pattern,
+			LinesPerSecond: rateLimit.LinesPerSecond,
+			Burst:          rateLimit.Burst,
+		})
+	}
+	for _, rule := range cfg.SamplingRules {
+		profile.SamplingRules = append(profile.SamplingRules, logger.SamplingRule{
+			Pattern:    rule.Pattern,
+			First:      rule.First,
+			Thereafter: rule.Thereafter,
+		})
+	}
+
+	if len(cfg.SuppressionReportInterval) > 0 {
+		interval, err := time.ParseDuration(cfg.SuppressionReportInterval)
+		if err != nil || interval <= 0 {
+			return createErrUnknownValue(ErrInvalidSuppressionReportInterval, cfg.SuppressionReportInterval)
+		}
+		profile.SuppressionReportInterval = interval
+	}
+
+	return profile.Validate().Err()
+}
+
+func buildDisplayByteSlice(mode string) (func(slice []byte) string, error) {
+	switch mode {
+	case "", ByteSliceDisplayHex:
+		return logger.ToHex, nil
+	case ByteSliceDisplayHexShort:
+		return logger.ToHexShort, nil
+	case ByteSliceDisplayBase64:
+		return logger.ToBase64, nil
+	case ByteSliceDisplayBase58:
+		return logger.ToBase58, nil
+	}
+
+	prefix := strings.TrimPrefix(mode, ByteSliceDisplayBech32+":")
+	if prefix == mode {
+		return nil, createErrUnknownValue(ErrUnknownByteSliceDisplay, mode)
+	}
+
+	return logger.NewBech32Display(prefix)
+}
+
+// wrapArgRenderingFormatter renders the arguments of the observer with its own byte slice displays, if any
+func wrapArgRenderingFormatter(cfg ObserverConfig, formatter logger.Formatter) (logger.Formatter, error) {
+	if len(cfg.ByteSliceDisplay) == 0 && len(cfg.ByteSliceDisplayByKey) == 0 {
+		return formatter, nil
+	}
+
+	registry := logger.NewArgRendererRegistry()
+	if len(cfg.ByteSliceDisplay) > 0 {
+		display, err := buildDisplayByteSlice(cfg.ByteSliceDisplay)
+		if err != nil {
+			return nil, err
+		}
+
+		err = registry.SetByteSliceDisplay(display)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// sorted, so that the overlapping globs are applied in the same order each time
+	keyPatterns := make([]string, 0, len(cfg.ByteSliceDisplayByKey))
+	for keyPattern := range cfg.ByteSliceDisplayByKey {
+		keyPatterns = append(keyPatterns, keyPattern)
+	}
+	sort.Strings(keyPatterns)
+	for _, keyPattern := range keyPatterns {
+		display, err := buildDisplayByteSlice(cfg.ByteSliceDisplayByKey[keyPattern])
+		if err != nil {
+			return nil, err
+		}
+
+		err = registry.SetKeyByteSliceDisplay(keyPattern, display)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return logger.NewArgRenderingFormatter(formatter, registry)
+}
+
+func (bc *builtConfig) addObserver(cfg ObserverConfig) error {
+	formatter, err := buildFormatter(cfg)
+	if err != nil {
+		return err
+	}
+
+	writer, err := bc.openWriter(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Async.Enabled {
+		queueSize := cfg.Async.QueueSize
+		if queueSize == 0 {
+			queueSize = DefaultAsyncQueueSize
+		}
+
+		asyncWriter, errAsync := logger.NewAsyncWriter(writer, queueSize)
+		if errAsync != nil {
+			return errAsync
+		}
+
+		bc.closers = append(bc.closers, asyncWriter)
+		writer = asyncWriter
+	}
+
+	if len(cfg.DedupWindow) > 0 {
+		window, errParse := time.ParseDuration(cfg.DedupWindow)
+		if errParse != nil || window <= 0 {
+			return createErrUnknownValue(logger.ErrInvalidDedupWindow, cfg.DedupWindow)
+		}
+
+		dedupObserver, errDedup := logger.NewDedupObserver(writer, formatter, window)
+		if errDedup != nil {
+			return errDedup
+		}
+
+		bc.closers = append(bc.closers, dedupObserver)
+		writer = dedupObserver
+		formatter = dedupObserver
+	}
+
+	formatter, err = wrapArgRenderingFormatter(cfg, formatter)
+	if err != nil {
+		return err
+	}
+
+	bc.writers = append(bc.writers, writer)
+	bc.formatters = append(bc.formatters, formatter)
+
+	return nil
+}
+
+func buildFormatter(cfg ObserverConfig) (logger.Formatter, error) {
+	var formatter logger.Formatter
+	switch cfg.Formatter {
+	case "", FormatterConsole:
+		formatter = &logger.ConsoleFormatter{}
+	case FormatterPlain:
+		formatter = &logger.PlainFormatter{}
+	case FormatterJSON:
+		wrapperFormatter, err := logger.NewLogLineWrapperFormatter(&marshal.JSONMarshalizer{})
+		if err != nil {
+			return nil, err
+		}
+		formatter = &jsonLinesFormatter{formatter: wrapperFormatter}
+	default:
+		return nil, createErrUnknownValue(ErrUnknownFormatter, cfg.Formatter)
+	}
+
+	if len(cfg.CorrelationFilter) > 0 {
+		var err error
+		formatter, err = logger.NewCorrelationFilterFormatter(formatter, cfg.CorrelationFilter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cfg.Level) == 0 {
+		return formatter, nil
+	}
+
+	logLevel, err := logger.GetLogLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	return logger.NewLevelFilterFormatter(formatter, logLevel)
+}
+
+func (bc *builtConfig) openWriter(cfg ObserverConfig) (io.Writer, error) {
+	switch cfg.Type {
+	case "", ObserverStdout:
+		return os.Stdout, nil
+	case ObserverStderr:
+		return os.Stderr, nil
+	case ObserverFile:
+		if len(cfg.Path) == 0 {
+			return nil, ErrMissingObserverPath
+		}
+
+		file, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, fileMode)
+		if err != nil {
+			return nil, err
+		}
+		bc.closers = append(bc.closers, file)
+
+		return file, nil
+	case ObserverTCP, ObserverUDP, ObserverUnix:
+		if len(cfg.Endpoint) == 0 {
+			return nil, ErrMissingObserverEndpoint
+		}
+
+		conn, err := net.DialTimeout(cfg.Type, cfg.Endpoint, dialTimeout)
+		if err != nil {
+			return nil, err
+		}
+		bc.closers = append(bc.closers, conn)
+
+		return conn, nil
+	default:
+		return nil, createErrUnknownValue(ErrUnknownObserverType, cfg.Type)
+	}
+}
+
+// jsonLinesFormatter outputs the log lines as newline delimited JSON objects
+type jsonLinesFormatter struct {
+	formatter logger.Formatter
+}
+
+// Output converts the provided line into a JSON object followed by a new line
+func (jlf *jsonLinesFormatter) Output(line logger.LogLineHandler) []byte {
+	buff := jlf.formatter.Output(line)
+	if len(buff) == 0 {
+		return nil
+	}
+
+	return append(buff, '\n')
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (jlf *jsonLinesFormatter) IsInterfaceNil() bool {
+	return jlf == nil
+}
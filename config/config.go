@@ -0,0 +1,121 @@
+package config
+
+// The supported logging configuration file formats
+const (
+	FormatTOML = "toml"
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// The supported observer types
+const (
+	ObserverStdout = "stdout"
+	ObserverStderr = "stderr"
+	ObserverFile   = "file"
+	ObserverTCP    = "tcp"
+	ObserverUDP    = "udp"
+	ObserverUnix   = "unix"
+)
+
+// The supported observer formatters
+const (
+	FormatterConsole = "console"
+	FormatterPlain   = "plain"
+	FormatterJSON    = "json"
+)
+
+// The supported byte slice display modes. The bech32 mode is followed by the human readable prefix: "bech32:erd".
+const (
+	ByteSliceDisplayHex      = "hex"
+	ByteSliceDisplayHexShort = "hexShort"
+	ByteSliceDisplayBase64   = "base64"
+	ByteSliceDisplayBase58   = "base58"
+	ByteSliceDisplayBech32   = "bech32"
+)
+
+// DefaultAsyncQueueSize is the queue size used by the async observers that do not specify one
+const DefaultAsyncQueueSize = 1024
+
+// LoggingConfig describes the whole logging setup: the profile, the observers, the timestamp options
+// and the byte slice display mode
+type LoggingConfig struct {
+	Profile          ProfileConfig    `json:"Profile" yaml:"Profile" toml:"Profile"`
+	Observers        []ObserverConfig `json:"Observers" yaml:"Observers" toml:"Observers"`
+	Timestamp        TimestampConfig  `json:"Timestamp" yaml:"Timestamp" toml:"Timestamp"`
+	ByteSliceDisplay string           `json:"ByteSliceDisplay" yaml:"ByteSliceDisplay" toml:"ByteSliceDisplay"`
+}
+
+// ProfileConfig describes the logger profile. An empty log level pattern stands for "*:INFO".
+type ProfileConfig struct {
+	LogLevelPatterns   string                `json:"LogLevelPatterns" yaml:"LogLevelPatterns" toml:"LogLevelPatterns"`
+	WithCorrelation    bool                  `json:"WithCorrelation" yaml:"WithCorrelation" toml:"WithCorrelation"`
+	WithLoggerName     bool                  `json:"WithLoggerName" yaml:"WithLoggerName" toml:"WithLoggerName"`
+	LogLevelOverrides  []OverrideConfig      `json:"LogLevelOverrides" yaml:"LogLevelOverrides" toml:"LogLevelOverrides"`
+	CorrelationFilter  string                `json:"CorrelationFilter" yaml:"CorrelationFilter" toml:"CorrelationFilter"`
+	CallerPatterns     string                `json:"CallerPatterns" yaml:"CallerPatterns" toml:"CallerPatterns"`
+	StackTracePatterns string                `json:"StackTracePatterns" yaml:"StackTracePatterns" toml:"StackTracePatterns"`
+	RateLimits         []RateLimitConfig     `json:"RateLimits" yaml:"RateLimits" toml:"RateLimits"`
+	SamplingRules      []SamplingRuleConfig  `json:"SamplingRules" yaml:"SamplingRules" toml:"SamplingRules"`
+	RedactionRules     []RedactionRuleConfig `json:"RedactionRules" yaml:"RedactionRules" toml:"RedactionRules"`
+	// SuppressionReportInterval is expressed as a Go duration string (e.g. "5m"), empty for the default interval
+	SuppressionReportInterval string `json:"SuppressionReportInterval" yaml:"SuppressionReportInterval" toml:"SuppressionReportInterval"`
+}
+
+// RateLimitConfig describes a token bucket rate limit of the lines of the loggers matching the pattern
+type RateLimitConfig struct {
+	Pattern        string  `json:"Pattern" yaml:"Pattern" toml:"Pattern"`
+	LinesPerSecond float64 `json:"LinesPerSecond" yaml:"LinesPerSecond" toml:"LinesPerSecond"`
+	Burst          int     `json:"Burst" yaml:"Burst" toml:"Burst"`
+}
+
+// SamplingRuleConfig describes the "first N then every Mth" sampling of the lines of the loggers matching the pattern
+type SamplingRuleConfig struct {
+	Pattern    string `json:"Pattern" yaml:"Pattern" toml:"Pattern"`
+	First      int    `json:"First" yaml:"First" toml:"First"`
+	Thereafter int    `json:"Thereafter" yaml:"Thereafter" toml:"Thereafter"`
+}
+
+// RedactionRuleConfig describes the masking (full, partial or hash) of the arguments following the ones named by Key
+// (exact or glob), of the argument parts matching the Value regular expression or of the Sensitive arguments
+type RedactionRuleConfig struct {
+	Key       string `json:"Key" yaml:"Key" toml:"Key"`
+	Value     string `json:"Value" yaml:"Value" toml:"Value"`
+	Sensitive bool   `json:"Sensitive" yaml:"Sensitive" toml:"Sensitive"`
+	Strategy  string `json:"Strategy" yaml:"Strategy" toml:"Strategy"`
+}
+
+// OverrideConfig describes a temporary log level pattern. The duration is expressed as a Go duration string (e.g. "10m").
+type OverrideConfig struct {
+	Pattern  string `json:"Pattern" yaml:"Pattern" toml:"Pattern"`
+	Duration string `json:"Duration" yaml:"Duration" toml:"Duration"`
+}
+
+// ObserverConfig describes a log observer: where the log lines are written, how they are formatted, the minimum
+// log level and the correlation filter of the written lines, whether the lines are written asynchronously and the
+// window (a Go duration string such as "10s") within which the repeated lines are collapsed
+type ObserverConfig struct {
+	Type              string      `json:"Type" yaml:"Type" toml:"Type"`
+	Path              string      `json:"Path" yaml:"Path" toml:"Path"`
+	Endpoint          string      `json:"Endpoint" yaml:"Endpoint" toml:"Endpoint"`
+	Formatter         string      `json:"Formatter" yaml:"Formatter" toml:"Formatter"`
+	Level             string      `json:"Level" yaml:"Level" toml:"Level"`
+	CorrelationFilter string      `json:"CorrelationFilter" yaml:"CorrelationFilter" toml:"CorrelationFilter"`
+	Async             AsyncConfig `json:"Async" yaml:"Async" toml:"Async"`
+	DedupWindow       string      `json:"DedupWindow" yaml:"DedupWindow" toml:"DedupWindow"`
+	// ByteSliceDisplay overrides the global byte slice display mode for this observer, while ByteSliceDisplayByKey
+	// sets the display mode of the byte slices following the matching argument keys (exact names or globs)
+	ByteSliceDisplay      string            `json:"ByteSliceDisplay" yaml:"ByteSliceDisplay" toml:"ByteSliceDisplay"`
+	ByteSliceDisplayByKey map[string]string `json:"ByteSliceDisplayByKey" yaml:"ByteSliceDisplayByKey" toml:"ByteSliceDisplayByKey"`
+}
+
+// AsyncConfig describes the asynchronous writing of an observer
+type AsyncConfig struct {
+	Enabled   bool `json:"Enabled" yaml:"Enabled" toml:"Enabled"`
+	QueueSize int  `json:"QueueSize" yaml:"QueueSize" toml:"QueueSize"`
+}
+
+// TimestampConfig describes how the log lines' timestamps are displayed. An empty format stands for the default one.
+type TimestampConfig struct {
+	Format string `json:"Format" yaml:"Format" toml:"Format"`
+	UTC    bool   `json:"UTC" yaml:"UTC" toml:"UTC"`
+}
@@ -0,0 +1,336 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/stretchr/testify/require"
+)
+
+const tomlConfig = `
+ByteSliceDisplay = "hexShort"
+
+[Profile]
+    LogLevelPatterns = "*:DEBUG"
+    WithLoggerName = true
+
+    [[Profile.LogLevelOverrides]]
+        Pattern = "config:TRACE"
+        Duration = "10m"
+
+[Timestamp]
+    Format = "15:04:05"
+    UTC = true
+
+[[Observers]]
+    Type = "file"
+    Path = "app.log"
+    Formatter = "json"
+    Level = "warn"
+    [Observers.Async]
+        Enabled = true
+        QueueSize = 16
+`
+
+const yamlConfig = `
+ByteSliceDisplay: hexShort
+Profile:
+  LogLevelPatterns: "*:DEBUG"
+  WithLoggerName: true
+  LogLevelOverrides:
+    - Pattern: "config:TRACE"
+      Duration: 10m
+Timestamp:
+  Format: "15:04:05"
+  UTC: true
+Observers:
+  - Type: file
+    Path: app.log
+    Formatter: json
+    Level: warn
+    Async:
+      Enabled: true
+      QueueSize: 16
+`
+
+const jsonConfig = `{
+  "ByteSliceDisplay": "hexShort",
+  "Profile": {
+    "LogLevelPatterns": "*:DEBUG",
+    "WithLoggerName": true,
+    "LogLevelOverrides": [{"Pattern": "config:TRACE", "Duration": "10m"}]
+  },
+  "Timestamp": {"Format": "15:04:05", "UTC": true},
+  "Observers": [
+    {"Type": "file", "Path": "app.log", "Formatter": "json", "Level": "warn", "Async": {"Enabled": true, "QueueSize": 16}}
+  ]
+}`
+
+func restoreLoggingSetup() func() {
+	state := captureLoggingState()
+	writers, formatters := logger.GetLogObservers()
+	previousOwnedWriters, previousOwnedFormatters := ownedWriters, ownedFormatters
+
+	return func() {
+		logger.ClearLogLevelOverrides()
+		restoreLoggingState(state)
+		_ = logger.ReplaceLogObservers(writers, formatters)
+
+		mutApply.Lock()
+		if appliedConfig != nil {
+			appliedConfig.close()
+			appliedConfig = nil
+		}
+		ownedWriters, ownedFormatters = previousOwnedWriters, previousOwnedFormatters
+		mutApply.Unlock()
+	}
+}
+
+// appliedFile returns the file opened by the applied configuration for the provided path
+func appliedFile(t *testing.T, path string) *os.File {
+	for _, closer := range appliedConfig.closers {
+		file, ok := closer.(*os.File)
+		if ok && file.Name() == path {
+			return file
+		}
+	}
+
+	require.Fail(t, "file not opened by the applied configuration", path)
+	return nil
+}
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := ioutil.WriteFile(path, []byte(content), 0600)
+	require.Nil(t, err)
+
+	return path
+}
+
+func TestLoadConfig_AllFormatsShouldParseTheSameConfig(t *testing.T) {
+	t.Parallel()
+
+	expected := &LoggingConfig{
+		Profile: ProfileConfig{
+			LogLevelPatterns:  "*:DEBUG",
+			WithLoggerName:    true,
+			LogLevelOverrides: []OverrideConfig{{Pattern: "config:TRACE", Duration: "10m"}},
+		},
+		Observers: []ObserverConfig{{
+			Type:      ObserverFile,
+			Path:      "app.log",
+			Formatter: FormatterJSON,
+			Level:     "warn",
+			Async:     AsyncConfig{Enabled: true, QueueSize: 16},
+		}},
+		Timestamp:        TimestampConfig{Format: "15:04:05", UTC: true},
+		ByteSliceDisplay: ByteSliceDisplayHexShort,
+	}
+
+	for name, content := range map[string]string{"log.toml": tomlConfig, "log.yml": yamlConfig, "log.json": jsonConfig} {
+		cfg, err := LoadConfig(writeConfigFile(t, name, content))
+		require.Nil(t, err, name)
+		require.Equal(t, expected, cfg, name)
+	}
+}
+
+func TestParseConfig_AllFormatsShouldRejectUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	for format, content := range map[string]string{
+		FormatTOML: "[Profile]\n    LogLevelPattern = \"*:DEBUG\"\n",
+		FormatYAML: "Profile:\n    LogLevelPattern: \"*:DEBUG\"\n",
+		FormatJSON: `{"Profile": {"LogLevelPattern": "*:DEBUG"}}`,
+	} {
+		cfg, err := ParseConfig([]byte(content), format)
+		require.Nil(t, cfg, format)
+		require.NotNil(t, err, format)
+		require.Contains(t, err.Error(), "LogLevelPattern", format)
+	}
+}
+
+func TestLoadConfig_UnknownExtensionShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := LoadConfig("log.ini")
+	require.Nil(t, cfg)
+	require.True(t, errors.Is(err, ErrUnknownConfigFormat))
+}
+
+func TestApplyConfig_ShouldApplyWholeSetup(t *testing.T) {
+	defer restoreLoggingSetup()()
+
+	log := logger.GetOrCreate("config/apply")
+	logPath := filepath.Join(t.TempDir(), "app.log")
+	cfg, err := ParseConfig([]byte(strings.Replace(jsonConfig, "app.log", filepath.ToSlash(logPath), 1)), FormatJSON)
+	require.Nil(t, err)
+
+	err = ApplyConfig(cfg)
+	require.Nil(t, err)
+
+	profile := logger.GetCurrentProfile()
+	require.Equal(t, "*:DEBUG", profile.LogLevelPatterns)
+	require.True(t, profile.WithLoggerName)
+	require.Equal(t, 1, len(profile.LogLevelOverrides))
+	require.Equal(t, logger.TimestampOptions{Format: "15:04:05", UTC: true}, logger.GetTimestampOptions())
+	require.Equal(t, "aabbcc..ddeeff", logger.DisplayByteSlice([]byte{0xaa, 0xbb, 0xcc, 0x00, 0xdd, 0xee, 0xff}))
+
+	require.Equal(t, logger.LogTrace, log.GetLevel())
+	log.Info("filtered out")
+	log.Warn("written")
+
+	writers, _ := logger.GetLogObservers()
+	require.Equal(t, 1, len(writers))
+	require.Nil(t, logger.GetLogOutputSubject().(logger.Flusher).Flush())
+
+	data, err := ioutil.ReadFile(logPath)
+	require.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Equal(t, 1, len(lines))
+	require.Contains(t, lines[0], `"Message":"written"`)
+}
+
+func TestApplyConfig_ShouldKeepTheObserversAddedByOtherComponents(t *testing.T) {
+	defer restoreLoggingSetup()()
+
+	foreign := &bytes.Buffer{}
+	require.Nil(t, logger.AddLogObserver(foreign, &logger.PlainFormatter{}))
+
+	dir := t.TempDir()
+	for _, name := range []string{"first.log", "second.log"} {
+		err := ApplyConfig(&LoggingConfig{
+			Observers: []ObserverConfig{{Type: ObserverFile, Path: filepath.Join(dir, name)}},
+		})
+		require.Nil(t, err)
+	}
+
+	err := ApplyConfig(&LoggingConfig{Observers: []ObserverConfig{{Type: "carrier-pigeon"}}})
+	require.NotNil(t, err)
+	err = ApplyConfig(&LoggingConfig{Profile: ProfileConfig{LogLevelPatterns: "*:FOO"}})
+	require.NotNil(t, err)
+
+	writers, _ := logger.GetLogObservers()
+	require.Equal(t, 2, len(writers))
+	require.Equal(t, appliedFile(t, filepath.Join(dir, "second.log")), writers[0])
+	require.Equal(t, foreign, writers[1])
+}
+
+func TestApplyConfig_FailureShouldRollBack(t *testing.T) {
+	defer restoreLoggingSetup()()
+
+	dir := t.TempDir()
+	firstPath := filepath.Join(dir, "first.log")
+	err := ApplyConfig(&LoggingConfig{
+		Profile:   ProfileConfig{LogLevelPatterns: "*:WARN"},
+		Observers: []ObserverConfig{{Type: ObserverFile, Path: firstPath}},
+	})
+	require.Nil(t, err)
+	previousProfile := logger.GetCurrentProfile()
+	previousWriters, _ := logger.GetLogObservers()
+	previousFile := appliedFile(t, firstPath)
+
+	err = ApplyConfig(&LoggingConfig{
+		Profile: ProfileConfig{LogLevelPatterns: "*:TRACE"},
+		Observers: []ObserverConfig{
+			{Type: ObserverFile, Path: filepath.Join(dir, "second.log")},
+			{Type: "carrier-pigeon"},
+		},
+	})
+	require.True(t, errors.Is(err, ErrUnknownObserverType))
+	require.Contains(t, err.Error(), "observer 1")
+
+	err = ApplyConfig(&LoggingConfig{Profile: ProfileConfig{LogLevelPatterns: "*:FOO"}})
+	require.NotNil(t, err)
+
+	err = ApplyConfig(&LoggingConfig{Observers: []ObserverConfig{{CorrelationFilter: "round>=many"}}})
+	require.True(t, errors.Is(err, logger.ErrInvalidCorrelationFilter))
+
+	err = ApplyConfig(&LoggingConfig{Profile: ProfileConfig{
+		LogLevelOverrides: []OverrideConfig{{Pattern: "*:TRACE", Duration: "forever"}},
+	}})
+	require.True(t, errors.Is(err, ErrInvalidOverrideDuration))
+
+	err = ApplyConfig(&LoggingConfig{Observers: []ObserverConfig{{DedupWindow: "often"}}})
+	require.True(t, errors.Is(err, logger.ErrInvalidDedupWindow))
+
+	err = ApplyConfig(&LoggingConfig{Observers: []ObserverConfig{{ByteSliceDisplay: "base32"}}})
+	require.True(t, errors.Is(err, ErrUnknownByteSliceDisplay))
+
+	err = ApplyConfig(&LoggingConfig{Observers: []ObserverConfig{{ByteSliceDisplayByKey: map[string]string{"address": "bech32:ERD"}}}})
+	require.True(t, errors.Is(err, logger.ErrInvalidBech32Prefix))
+
+	err = ApplyConfig(&LoggingConfig{Profile: ProfileConfig{
+		RedactionRules: []RedactionRuleConfig{{Key: "token", Strategy: "blur"}},
+	}})
+	require.True(t, errors.Is(err, logger.ErrInvalidProfile))
+	require.Contains(t, err.Error(), "RedactionRules")
+
+	writers, _ := logger.GetLogObservers()
+	require.Equal(t, previousWriters, writers)
+	require.Equal(t, previousProfile, logger.GetCurrentProfile())
+	_, err = previousFile.Write([]byte("still open"))
+	require.Nil(t, err)
+}
+
+func TestApplyConfig_ObserverByteSliceDisplays(t *testing.T) {
+	defer restoreLoggingSetup()()
+
+	dir := t.TempDir()
+	err := ApplyConfig(&LoggingConfig{
+		Profile: ProfileConfig{LogLevelPatterns: "*:INFO"},
+		Observers: []ObserverConfig{
+			{Type: ObserverFile, Path: filepath.Join(dir, "default.log"), Formatter: FormatterPlain},
+			{
+				Type:             ObserverFile,
+				Path:             filepath.Join(dir, "custom.log"),
+				Formatter:        FormatterPlain,
+				ByteSliceDisplay: ByteSliceDisplayBase58,
+				ByteSliceDisplayByKey: map[string]string{
+					"signature": ByteSliceDisplayBase64,
+					"address":   ByteSliceDisplayBech32 + ":erd",
+				},
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	logger.GetOrCreate("config/bytes").Info("transaction", "hash", []byte{0, 0, 1}, "signature", []byte{0, 0, 1},
+		"address", make([]byte, 32))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "default.log"))
+	require.Nil(t, err)
+	require.Contains(t, string(data), "hash = 000001 signature = 000001")
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "custom.log"))
+	require.Nil(t, err)
+	require.Contains(t, string(data), "hash = 112 signature = AAAB address = erd1"+strings.Repeat("q", 52)+"6gq4hu")
+}
+
+func TestApplyConfig_ShouldCloseThePreviousResources(t *testing.T) {
+	defer restoreLoggingSetup()()
+
+	dir := t.TempDir()
+	firstPath := filepath.Join(dir, "first.log")
+	err := ApplyConfig(&LoggingConfig{
+		Observers: []ObserverConfig{{Type: ObserverFile, Path: firstPath}},
+	})
+	require.Nil(t, err)
+	previousFile := appliedFile(t, firstPath)
+
+	err = ApplyConfig(&LoggingConfig{
+		Observers: []ObserverConfig{{Type: ObserverStderr, Async: AsyncConfig{Enabled: true}}},
+	})
+	require.Nil(t, err)
+
+	_, err = previousFile.Write([]byte("closed"))
+	require.NotNil(t, err)
+	require.Equal(t, logger.TimestampOptions{Format: logger.DefaultTimestampFormat}, logger.GetTimestampOptions())
+	require.Equal(t, "*:INFO", logger.GetLogLevelPattern())
+	require.Empty(t, logger.GetLogLevelOverrides())
+}
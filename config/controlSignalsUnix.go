@@ -0,0 +1,13 @@
+//go:build !windows
+// +build !windows
+
+package config
+
+import (
+	"os"
+	"syscall"
+)
+
+func getControlSignals() (verbose os.Signal, quiet os.Signal, reload os.Signal, err error) {
+	return syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGHUP, nil
+}
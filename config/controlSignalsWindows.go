@@ -0,0 +1,10 @@
+//go:build windows
+// +build windows
+
+package config
+
+import "os"
+
+func getControlSignals() (verbose os.Signal, quiet os.Signal, reload os.Signal, err error) {
+	return nil, nil, nil, ErrSignalsNotSupported
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNilConfig signals that a nil logging configuration has been provided
+var ErrNilConfig = errors.New("nil logging configuration")
+
+// ErrUnknownConfigFormat signals that the logging configuration format could not be determined
+var ErrUnknownConfigFormat = errors.New("unknown logging configuration format")
+
+// ErrUnknownObserverType signals that an unknown observer type has been provided
+var ErrUnknownObserverType = errors.New("unknown observer type")
+
+// ErrUnknownFormatter signals that an unknown formatter has been provided
+var ErrUnknownFormatter = errors.New("unknown formatter")
+
+// ErrMissingObserverPath signals that a file observer has been configured without a path
+var ErrMissingObserverPath = errors.New("missing observer path")
+
+// ErrMissingObserverEndpoint signals that a network observer has been configured without an endpoint
+var ErrMissingObserverEndpoint = errors.New("missing observer endpoint")
+
+// ErrUnknownByteSliceDisplay signals that an unknown byte slice display mode has been provided
+var ErrUnknownByteSliceDisplay = errors.New("unknown byte slice display mode")
+
+// ErrInvalidOverrideDuration signals that an invalid log level override duration has been provided
+var ErrInvalidOverrideDuration = errors.New("invalid log level override duration")
+
+// ErrInvalidSuppressionReportInterval signals that an invalid suppressed lines report interval has been provided
+var ErrInvalidSuppressionReportInterval = errors.New("invalid suppression report interval")
+
+// ErrEmptyPath signals that an empty file path has been provided
+var ErrEmptyPath = errors.New("empty path")
+
+// ErrInvalidPollInterval signals that an invalid poll interval has been provided
+var ErrInvalidPollInterval = errors.New("invalid poll interval")
+
+// ErrFileSystemEventsNotSupported signals that the file system events are not supported on the current platform
+var ErrFileSystemEventsNotSupported = errors.New("file system events not supported")
+
+// ErrInvalidEnvironmentVariable signals that an environment variable has an invalid value
+var ErrInvalidEnvironmentVariable = errors.New("invalid environment variable")
+
+// ErrInvalidHistorySize signals that an invalid profile history size has been provided
+var ErrInvalidHistorySize = errors.New("invalid profile history size")
+
+// ErrInvalidRollback signals that the requested profile is not in the profile history
+var ErrInvalidRollback = errors.New("invalid rollback: profile not in history")
+
+// ErrSignalsNotSupported signals that the control signals are not supported on the current platform
+var ErrSignalsNotSupported = errors.New("control signals not supported")
+
+func createErrUnknownConfigFormat(path string) error {
+	return fmt.Errorf("%w for '%s'", ErrUnknownConfigFormat, path)
+}
+
+func createErrObserver(index int, err error) error {
+	return fmt.Errorf("observer %d: %w", index, err)
+}
+
+func createErrUnknownValue(err error, value string) error {
+	return fmt.Errorf("%w '%s'", err, value)
+}
+
+func createErrInvalidEnvironmentVariable(name string, value string) error {
+	return fmt.Errorf("%w %s='%s'", ErrInvalidEnvironmentVariable, name, value)
+}
@@ -0,0 +1,119 @@
+//go:build linux
+// +build linux
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_CREATE | syscall.IN_DELETE |
+	syscall.IN_MODIFY | syscall.IN_ATTRIB
+
+const inotifyBufferSize = 4096
+
+// fileSystemEvents signals the changes made to the watched file, using inotify
+type fileSystemEvents struct {
+	file     *os.File
+	names    map[string]struct{}
+	chEvents chan struct{}
+}
+
+func newFileSystemEvents(path string) (*fileSystemEvents, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+
+	// the directory is watched so that the files replaced by rename (or by swapping symlinks) are also caught, while
+	// the events of the other files of the directory (such as log files) are ignored
+	_, err = syscall.InotifyAddWatch(fd, filepath.Dir(path), inotifyMask)
+	if err != nil {
+		_ = syscall.Close(fd)
+		return nil, err
+	}
+
+	events := &fileSystemEvents{
+		file:     os.NewFile(uintptr(fd), "inotify"),
+		names:    watchedNames(path),
+		chEvents: make(chan struct{}, 1),
+	}
+	go events.continuouslyRead()
+
+	return events, nil
+}
+
+func (events *fileSystemEvents) continuouslyRead() {
+	defer close(events.chEvents)
+
+	buff := make([]byte, inotifyBufferSize)
+	for {
+		n, err := events.file.Read(buff)
+		if err != nil {
+			return
+		}
+		if !events.isAnyWatched(buff[:n]) {
+			continue
+		}
+
+		select {
+		case events.chEvents <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// isAnyWatched returns whether any of the read events concerns one of the watched names. An overflow of the events
+// queue is also reported, as the lost events might have concerned them.
+func (events *fileSystemEvents) isAnyWatched(buff []byte) bool {
+	for offset := 0; offset+syscall.SizeofInotifyEvent <= len(buff); {
+		event := (*syscall.InotifyEvent)(unsafe.Pointer(&buff[offset]))
+		if event.Mask&syscall.IN_Q_OVERFLOW != 0 {
+			return true
+		}
+
+		nameStart := offset + syscall.SizeofInotifyEvent
+		nameEnd := nameStart + int(event.Len)
+		if nameEnd > len(buff) {
+			return false
+		}
+		name := string(bytes.TrimRight(buff[nameStart:nameEnd], "\x00"))
+		_, ok := events.names[name]
+		if ok {
+			return true
+		}
+
+		offset = nameEnd
+	}
+
+	return false
+}
+
+// watchedNames returns the names, in the directory of the watched file, whose changes can change the file: its own
+// name and, when it is a symlink to a relative path (as the mounted Kubernetes ConfigMaps are), the first element of
+// the link's target, which is swapped when the content is updated
+func watchedNames(path string) map[string]struct{} {
+	names := map[string]struct{}{
+		filepath.Base(path): {},
+	}
+
+	target, err := os.Readlink(path)
+	if err != nil || filepath.IsAbs(target) {
+		return names
+	}
+	firstElement := strings.Split(filepath.ToSlash(filepath.Clean(target)), "/")[0]
+	if firstElement != "." && firstElement != ".." {
+		names[firstElement] = struct{}{}
+	}
+
+	return names
+}
+
+func (events *fileSystemEvents) close() {
+	_ = events.file.Close()
+}
@@ -0,0 +1,67 @@
+//go:build linux
+// +build linux
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requireFileSystemEvent(t *testing.T, events *fileSystemEvents, expected bool) {
+	select {
+	case <-events.chEvents:
+		require.True(t, expected, "unexpected event")
+	case <-time.After(100 * time.Millisecond):
+		require.False(t, expected, "missing event")
+	}
+}
+
+func TestFileSystemEvents_ShouldIgnoreTheOtherFilesOfTheDirectory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logging.toml")
+	require.Nil(t, ioutil.WriteFile(path, []byte("[Profile]\n"), 0600))
+
+	events, err := newFileSystemEvents(path)
+	require.Nil(t, err)
+	defer events.close()
+
+	logFile, err := os.Create(filepath.Join(dir, "node.log"))
+	require.Nil(t, err)
+	defer func() {
+		_ = logFile.Close()
+	}()
+	_, err = logFile.Write([]byte("a log line\n"))
+	require.Nil(t, err)
+	requireFileSystemEvent(t, events, false)
+
+	require.Nil(t, ioutil.WriteFile(path+".tmp", []byte("[Profile]\n"), 0600))
+	requireFileSystemEvent(t, events, false)
+	require.Nil(t, os.Rename(path+".tmp", path))
+	requireFileSystemEvent(t, events, true)
+}
+
+func TestFileSystemEvents_ShouldFollowTheSwappedSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	require.Nil(t, os.Mkdir(filepath.Join(dir, "..v1"), 0700))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "..v1", "logging.toml"), []byte("[Profile]\n"), 0600))
+	require.Nil(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+	path := filepath.Join(dir, "logging.toml")
+	require.Nil(t, os.Symlink(filepath.Join("..data", "logging.toml"), path))
+
+	events, err := newFileSystemEvents(path)
+	require.Nil(t, err)
+	defer events.close()
+
+	require.Nil(t, os.Mkdir(filepath.Join(dir, "..v2"), 0700))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "..v2", "logging.toml"), []byte("[Profile]\n"), 0600))
+	require.Nil(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	requireFileSystemEvent(t, events, false)
+	require.Nil(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	requireFileSystemEvent(t, events, true)
+}
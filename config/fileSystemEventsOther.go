@@ -0,0 +1,16 @@
+//go:build !linux
+// +build !linux
+
+package config
+
+// fileSystemEvents is not implemented on this platform, the watched files are polled
+type fileSystemEvents struct {
+	chEvents chan struct{}
+}
+
+func newFileSystemEvents(_ string) (*fileSystemEvents, error) {
+	return nil, ErrFileSystemEventsNotSupported
+}
+
+func (events *fileSystemEvents) close() {
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v2"
+)
+
+// LoadConfig reads and parses the logging configuration file. The file format is deduced from its extension
+// (.toml, .yaml, .yml or .json).
+func LoadConfig(path string) (*LoggingConfig, error) {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseConfig(data, format)
+}
+
+// FormatFromPath returns the configuration format corresponding to the extension of the provided path
+func FormatFromPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return FormatTOML, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", createErrUnknownConfigFormat(path)
+	}
+}
+
+// ParseConfig parses the logging configuration from the provided data, using the provided format. Whatever the
+// format, the unknown keys are rejected, so that a misspelled option does not go unnoticed.
+func ParseConfig(data []byte, format string) (*LoggingConfig, error) {
+	cfg := &LoggingConfig{}
+
+	var err error
+	switch format {
+	case FormatTOML:
+		err = toml.NewDecoder(bytes.NewReader(data)).Strict(true).Decode(cfg)
+	case FormatYAML:
+		err = yaml.UnmarshalStrict(data, cfg)
+	case FormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(cfg)
+	default:
+		return nil, createErrUnknownConfigFormat(format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"strconv"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+)
+
+// The command line flags defining the logger profile
+const (
+	FlagLogLevel       = "log-level"
+	FlagLogCorrelation = "log-correlation"
+	FlagLogLoggerName  = "log-logger-name"
+)
+
+// The environment variables defining the logger profile
+const (
+	EnvLogLevel       = "DME_LOG_LEVEL"
+	EnvLogCorrelation = "DME_LOG_CORRELATION"
+	EnvLogLoggerName  = "DME_LOG_LOGGER_NAME"
+)
+
+// ProfileFlags holds the logger profile options registered on a flag set
+type ProfileFlags struct {
+	flagSet         *flag.FlagSet
+	logLevel        string
+	withCorrelation bool
+	withLoggerName  bool
+}
+
+// RegisterProfileFlags registers the log-level, log-correlation and log-logger-name flags on the provided
+// flag set (flag.CommandLine if nil). The resulting profile is available, after parsing, through Profile.
+func RegisterProfileFlags(flagSet *flag.FlagSet) *ProfileFlags {
+	if flagSet == nil {
+		flagSet = flag.CommandLine
+	}
+
+	pf := &ProfileFlags{
+		flagSet: flagSet,
+	}
+	flagSet.StringVar(&pf.logLevel, FlagLogLevel, defaultLogLevelPattern,
+		"comma-separated pairs of (loggerName, logLevel), e.g. \"*:INFO,processor:DEBUG\" (env "+EnvLogLevel+")")
+	flagSet.BoolVar(&pf.withCorrelation, FlagLogCorrelation, false,
+		"include correlation elements in the logs (env "+EnvLogCorrelation+")")
+	flagSet.BoolVar(&pf.withLoggerName, FlagLogLoggerName, false,
+		"include logger name in the logs (env "+EnvLogLoggerName+")")
+
+	return pf
+}
+
+// Profile returns the validated profile resulting from, in order of precedence: the flags explicitly set,
+// the environment variables, the profile of the provided logging configuration (optional) and the defaults.
+// The precedence is applied on each setting separately.
+func (pf *ProfileFlags) Profile(cfg *LoggingConfig) (logger.Profile, error) {
+	profileConfig := ProfileConfig{}
+	if cfg != nil {
+		profileConfig = cfg.Profile
+	}
+
+	err := applyProfileEnvironment(&profileConfig)
+	if err != nil {
+		return logger.Profile{}, err
+	}
+
+	pf.flagSet.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case FlagLogLevel:
+			profileConfig.LogLevelPatterns = pf.logLevel
+		case FlagLogCorrelation:
+			profileConfig.WithCorrelation = pf.withCorrelation
+		case FlagLogLoggerName:
+			profileConfig.WithLoggerName = pf.withLoggerName
+		}
+	})
+
+	return buildProfile(profileConfig)
+}
+
+func applyProfileEnvironment(profileConfig *ProfileConfig) error {
+	logLevel, ok := os.LookupEnv(EnvLogLevel)
+	if ok && len(logLevel) > 0 {
+		profileConfig.LogLevelPatterns = logLevel
+	}
+
+	err := lookupBoolEnv(EnvLogCorrelation, &profileConfig.WithCorrelation)
+	if err != nil {
+		return err
+	}
+
+	return lookupBoolEnv(EnvLogLoggerName, &profileConfig.WithLoggerName)
+}
+
+func lookupBoolEnv(name string, dest *bool) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || len(value) == 0 {
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return createErrInvalidEnvironmentVariable(name, value)
+	}
+	*dest = parsed
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/stretchr/testify/require"
+)
+
+func createProfileFlags(t *testing.T, args ...string) *ProfileFlags {
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.SetOutput(ioutil.Discard)
+	pf := RegisterProfileFlags(flagSet)
+	require.Nil(t, flagSet.Parse(args))
+
+	return pf
+}
+
+func TestProfileFlags_DefaultsShouldApply(t *testing.T) {
+	profile, err := createProfileFlags(t).Profile(nil)
+	require.Nil(t, err)
+	require.Equal(t, logger.Profile{LogLevelPatterns: "*:INFO"}, profile)
+}
+
+func TestProfileFlags_PrecedenceShouldBeFlagEnvConfig(t *testing.T) {
+	cfg := &LoggingConfig{
+		Profile: ProfileConfig{LogLevelPatterns: "*:ERROR", WithCorrelation: true, WithLoggerName: true},
+	}
+
+	profile, err := createProfileFlags(t).Profile(cfg)
+	require.Nil(t, err)
+	require.Equal(t, logger.Profile{LogLevelPatterns: "*:ERROR", WithCorrelation: true, WithLoggerName: true}, profile)
+
+	t.Setenv(EnvLogLevel, "*:WARN")
+	t.Setenv(EnvLogLoggerName, "false")
+	profile, err = createProfileFlags(t).Profile(cfg)
+	require.Nil(t, err)
+	require.Equal(t, logger.Profile{LogLevelPatterns: "*:WARN", WithCorrelation: true}, profile)
+
+	profile, err = createProfileFlags(t, "--log-level", "*:DEBUG", "--log-correlation=false", "--log-logger-name").Profile(cfg)
+	require.Nil(t, err)
+	require.Equal(t, logger.Profile{LogLevelPatterns: "*:DEBUG", WithLoggerName: true}, profile)
+}
+
+func TestProfileFlags_InvalidValuesShouldErr(t *testing.T) {
+	_, err := createProfileFlags(t, "--log-level", "*:DEBG").Profile(nil)
+	require.NotNil(t, err)
+
+	t.Setenv(EnvLogCorrelation, "maybe")
+	_, err = createProfileFlags(t).Profile(nil)
+	require.True(t, errors.Is(err, ErrInvalidEnvironmentVariable))
+	require.Contains(t, err.Error(), EnvLogCorrelation)
+}
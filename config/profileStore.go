@@ -0,0 +1,277 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+)
+
+// The sources of the profiles applied by the profile store
+const (
+	ProfileSourceRestore  = "ProfileStore.Restore"
+	ProfileSourceRollback = "ProfileStore.Rollback"
+)
+
+// DefaultProfileHistorySize is the number of profiles kept by a profile store by default
+const DefaultProfileHistorySize = 16
+
+const stateFileMode = 0600
+
+// ProfileRecord is an applied profile, as persisted by the profile store
+type ProfileRecord struct {
+	Profile   logger.Profile
+	Timestamp time.Time
+	Source    string
+}
+
+type profileStoreState struct {
+	History []ProfileRecord
+}
+
+// profileStore persists each applied profile and each notified profile change in a state file, keeping a bounded
+// history
+type profileStore struct {
+	path           string
+	historySize    int
+	mut            sync.Mutex
+	history        []ProfileRecord
+	pendingSources map[string]string
+}
+
+// NewProfileStore creates a profile store persisting the profiles in the provided state file. The profiles already
+// recorded in the file (if any) are loaded, while the profiles are recorded from now on, each time one is applied
+// (see logger.Profile.Apply) or a profile change is notified (see logger.NotifyProfileChange and
+// logger.SetAutoNotifyProfileChange).
+func NewProfileStore(path string, historySize int) (*profileStore, error) {
+	if len(path) == 0 {
+		return nil, ErrEmptyPath
+	}
+	if historySize < 1 {
+		return nil, ErrInvalidHistorySize
+	}
+
+	ps := &profileStore{
+		path:           path,
+		historySize:    historySize,
+		pendingSources: make(map[string]string),
+	}
+
+	err := ps.load()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.SubscribeToProfileApply(ps)
+	logger.SubscribeToProfileChangeEvents(ps)
+
+	return ps, nil
+}
+
+func (ps *profileStore) load() error {
+	data, err := ioutil.ReadFile(ps.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	state := &profileStoreState{}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return err
+	}
+
+	ps.history = state.History
+	ps.trimHistory()
+
+	return nil
+}
+
+// OnProfileApplied records the applied profile
+func (ps *profileStore) OnProfileApplied(profile logger.Profile) {
+	ps.record(profile, logger.ProfileChangeSourceApply)
+}
+
+// OnProfileChangeEvent records the current profile
+func (ps *profileStore) OnProfileChangeEvent(event logger.ProfileChangeEvent) {
+	ps.record(event.Current, event.Source)
+}
+
+// record appends the provided profile to the history, unless it is the same as the most recent one
+func (ps *profileStore) record(profile logger.Profile, source string) {
+	ps.mut.Lock()
+	defer ps.mut.Unlock()
+
+	key := profileKey(profile)
+	pendingSource, isPending := ps.pendingSources[key]
+	if isPending {
+		source = pendingSource
+		delete(ps.pendingSources, key)
+	}
+
+	numRecords := len(ps.history)
+	if !isPending && numRecords > 0 && profileKey(ps.history[numRecords-1].Profile) == key {
+		return
+	}
+
+	ps.history = append(ps.history, ProfileRecord{
+		Profile:   profile,
+		Timestamp: time.Now(),
+		Source:    source,
+	})
+	ps.trimHistory()
+
+	err := ps.save()
+	if err != nil {
+		log.Error("cannot save the profile store", "file", ps.path, "error", err.Error())
+	}
+}
+
+func (ps *profileStore) trimHistory() {
+	if len(ps.history) > ps.historySize {
+		ps.history = ps.history[len(ps.history)-ps.historySize:]
+	}
+}
+
+// save writes the state file atomically
+func (ps *profileStore) save() error {
+	data, err := json.MarshalIndent(&profileStoreState{History: ps.history}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(ps.path), filepath.Base(ps.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	_, err = tmpFile.Write(data)
+	if err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	err = tmpFile.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(tmpFile.Name(), stateFileMode)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), ps.path)
+}
+
+// History returns the recorded profiles, the most recent one being the last
+func (ps *profileStore) History() []ProfileRecord {
+	ps.mut.Lock()
+	defer ps.mut.Unlock()
+
+	return append([]ProfileRecord(nil), ps.history...)
+}
+
+// Restore applies the most recently recorded profile, if any. The remaining durations of the log level overrides
+// are reduced by the time elapsed since the profile was recorded.
+func (ps *profileStore) Restore() error {
+	return ps.applyRecord(0, ProfileSourceRestore)
+}
+
+// Rollback re-applies the profile recorded n changes before the most recent one (n = 1 being the previous profile)
+func (ps *profileStore) Rollback(n int) error {
+	if n < 1 {
+		return ErrInvalidRollback
+	}
+
+	return ps.applyRecord(n, ProfileSourceRollback)
+}
+
+func (ps *profileStore) applyRecord(n int, source string) error {
+	ps.mut.Lock()
+	numRecords := len(ps.history)
+	if numRecords == 0 && n == 0 {
+		ps.mut.Unlock()
+		return nil
+	}
+	if n >= numRecords {
+		ps.mut.Unlock()
+		return ErrInvalidRollback
+	}
+
+	record := ps.history[numRecords-1-n]
+	profile := profileAt(record, time.Now())
+	ps.pendingSources[profileKey(profile)] = source
+	ps.mut.Unlock()
+
+	err := profile.Apply()
+	if err != nil {
+		ps.mut.Lock()
+		delete(ps.pendingSources, profileKey(profile))
+		ps.mut.Unlock()
+
+		return err
+	}
+
+	logger.NotifyProfileChange()
+	return nil
+}
+
+// profileAt returns the recorded profile, without the log level overrides expired at the provided time
+func profileAt(record ProfileRecord, now time.Time) logger.Profile {
+	profile := record.Profile
+	profile.LogLevelOverrides = nil
+
+	elapsed := now.Sub(record.Timestamp)
+	for _, override := range record.Profile.LogLevelOverrides {
+		override.Remaining -= elapsed
+		if override.Remaining > 0 {
+			profile.LogLevelOverrides = append(profile.LogLevelOverrides, override)
+		}
+	}
+
+	return profile
+}
+
+// profileKey identifies the settings of a profile, ignoring the remaining durations of the log level overrides
+func profileKey(profile logger.Profile) string {
+	elements := []string{
+		profile.LogLevelPatterns,
+		strconv.FormatBool(profile.WithCorrelation),
+		strconv.FormatBool(profile.WithLoggerName),
+		profile.CorrelationFilter,
+		profile.CallerPatterns,
+		profile.StackTracePatterns,
+		fmt.Sprintf("%v", profile.RateLimits),
+		fmt.Sprintf("%v", profile.SamplingRules),
+		profile.SuppressionReportInterval.String(),
+		fmt.Sprintf("%v", profile.RedactionRules),
+	}
+	for _, override := range profile.LogLevelOverrides {
+		elements = append(elements, override.Pattern)
+	}
+
+	return strings.Join(elements, "|")
+}
+
+// Close stops recording the profiles
+func (ps *profileStore) Close() error {
+	logger.UnsubscribeFromProfileApply(ps)
+	logger.UnsubscribeFromProfileChangeEvents(ps)
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ps *profileStore) IsInterfaceNil() bool {
+	return ps == nil
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/stretchr/testify/require"
+)
+
+func applyAndNotify(t *testing.T, profile logger.Profile) {
+	require.Nil(t, profile.Apply())
+	logger.NotifyProfileChange()
+}
+
+func waitForHistory(t *testing.T, ps *profileStore, numRecords int) []ProfileRecord {
+	require.Eventually(t, func() bool {
+		return len(ps.History()) == numRecords
+	}, time.Second, 5*time.Millisecond)
+
+	return ps.History()
+}
+
+func TestNewProfileStore_InvalidArgumentsShouldErr(t *testing.T) {
+	ps, err := NewProfileStore("", 1)
+	require.True(t, ps.IsInterfaceNil())
+	require.Equal(t, ErrEmptyPath, err)
+
+	ps, err = NewProfileStore("state.json", 0)
+	require.True(t, ps.IsInterfaceNil())
+	require.Equal(t, ErrInvalidHistorySize, err)
+}
+
+func TestProfileStore_ShouldPersistAndRestore(t *testing.T) {
+	defer restoreLoggingSetup()()
+
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	ps, err := NewProfileStore(path, 2)
+	require.Nil(t, err)
+	require.Nil(t, ps.Restore())
+
+	applyAndNotify(t, logger.Profile{LogLevelPatterns: "*:DEBUG"})
+	waitForHistory(t, ps, 1)
+	applyAndNotify(t, logger.Profile{LogLevelPatterns: "*:TRACE", WithLoggerName: true})
+	waitForHistory(t, ps, 2)
+	applyAndNotify(t, logger.Profile{
+		LogLevelPatterns:  "*:WARN",
+		LogLevelOverrides: []logger.LogLevelOverride{{Pattern: "store:TRACE", Remaining: time.Hour}},
+	})
+	require.Eventually(t, func() bool {
+		history := ps.History()
+		return len(history) == 2 && history[1].Profile.LogLevelPatterns == "*:WARN"
+	}, time.Second, 5*time.Millisecond)
+
+	history := ps.History()
+	require.Equal(t, "*:TRACE", history[0].Profile.LogLevelPatterns)
+	require.Equal(t, logger.ProfileChangeSourceApply, history[1].Source)
+	require.Nil(t, ps.Close())
+
+	// the node restarts with the default profile
+	require.Nil(t, (&logger.Profile{LogLevelPatterns: "*:INFO"}).Apply())
+
+	ps, err = NewProfileStore(path, 2)
+	require.Nil(t, err)
+	defer func() {
+		_ = ps.Close()
+	}()
+	require.Equal(t, 2, len(ps.History()))
+
+	require.Nil(t, ps.Restore())
+	profile := logger.GetCurrentProfile()
+	require.Equal(t, "*:WARN", profile.LogLevelPatterns)
+	require.Equal(t, 1, len(profile.LogLevelOverrides))
+	require.True(t, profile.LogLevelOverrides[0].Remaining > 59*time.Minute)
+
+	history = waitForHistory(t, ps, 2)
+	require.Equal(t, ProfileSourceRestore, history[1].Source)
+}
+
+func TestProfileStore_Rollback(t *testing.T) {
+	defer restoreLoggingSetup()()
+
+	ps, err := NewProfileStore(filepath.Join(t.TempDir(), "profiles.json"), DefaultProfileHistorySize)
+	require.Nil(t, err)
+	defer func() {
+		_ = ps.Close()
+	}()
+
+	require.Equal(t, ErrInvalidRollback, ps.Rollback(0))
+	require.Equal(t, ErrInvalidRollback, ps.Rollback(1))
+
+	applyAndNotify(t, logger.Profile{LogLevelPatterns: "*:DEBUG"})
+	waitForHistory(t, ps, 1)
+	applyAndNotify(t, logger.Profile{LogLevelPatterns: "*:TRACE"})
+	waitForHistory(t, ps, 2)
+	applyAndNotify(t, logger.Profile{LogLevelPatterns: "*:ERROR"})
+	waitForHistory(t, ps, 3)
+
+	require.Nil(t, ps.Rollback(2))
+	require.Equal(t, "*:DEBUG", logger.GetLogLevelPattern())
+
+	history := waitForHistory(t, ps, 4)
+	require.Equal(t, "*:DEBUG", history[3].Profile.LogLevelPatterns)
+	require.Equal(t, ProfileSourceRollback, history[3].Source)
+
+	require.Equal(t, ErrInvalidRollback, ps.Rollback(4))
+}
+
+func TestProfileStore_ShouldRecordTheAppliedProfilesWithoutNotifications(t *testing.T) {
+	defer restoreLoggingSetup()()
+
+	ps, err := NewProfileStore(filepath.Join(t.TempDir(), "profiles.json"), DefaultProfileHistorySize)
+	require.Nil(t, err)
+	defer func() {
+		_ = ps.Close()
+	}()
+
+	require.Nil(t, (&logger.Profile{LogLevelPatterns: "*:DEBUG"}).Apply())
+	require.Nil(t, (&logger.Profile{LogLevelPatterns: "*:TRACE"}).Apply())
+
+	history := ps.History()
+	require.Equal(t, 2, len(history))
+	require.Equal(t, "*:DEBUG", history[0].Profile.LogLevelPatterns)
+	require.Equal(t, "*:TRACE", history[1].Profile.LogLevelPatterns)
+	require.Equal(t, logger.ProfileChangeSourceApply, history[1].Source)
+
+	logger.NotifyProfileChange()
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, 2, len(ps.History()))
+}
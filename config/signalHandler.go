@@ -0,0 +1,193 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+)
+
+const signalsQueueSize = 4
+
+// steppedLogLevels are the levels the signals step through
+var steppedLogLevels = []logger.LogLevel{
+	logger.LogTrace,
+	logger.LogDebug,
+	logger.LogInfo,
+	logger.LogWarning,
+	logger.LogError,
+}
+
+// signalHandler changes the logger profile on the received signals: SIGUSR1 makes the global log level
+// (the "*" rules) one step more verbose, SIGUSR2 one step less verbose and SIGHUP reloads the profile file
+type signalHandler struct {
+	profilePath   string
+	verboseSignal os.Signal
+	quietSignal   os.Signal
+	reloadSignal  os.Signal
+	chSignals     chan os.Signal
+	chClose       chan struct{}
+	chDone        chan struct{}
+	mutUninstall  sync.Mutex
+	isUninstalled bool
+}
+
+// InstallSignalHandler starts handling the SIGUSR1 (more verbose global log level), SIGUSR2 (less verbose global
+// log level) and, if a profile file is provided, SIGHUP (reload the marshalized profile file, see
+// logger.Profile.Marshal) signals. Each change is followed by logger.NotifyProfileChange. Call Uninstall to
+// restore the default behavior of the signals.
+func InstallSignalHandler(profilePath string) (*signalHandler, error) {
+	verboseSignal, quietSignal, reloadSignal, err := getControlSignals()
+	if err != nil {
+		return nil, err
+	}
+
+	sh := &signalHandler{
+		profilePath:   profilePath,
+		verboseSignal: verboseSignal,
+		quietSignal:   quietSignal,
+		reloadSignal:  reloadSignal,
+		chSignals:     make(chan os.Signal, signalsQueueSize),
+		chClose:       make(chan struct{}),
+		chDone:        make(chan struct{}),
+	}
+
+	signals := []os.Signal{verboseSignal, quietSignal}
+	if len(profilePath) > 0 {
+		signals = append(signals, reloadSignal)
+	}
+	signal.Notify(sh.chSignals, signals...)
+
+	go sh.continuouslyHandleSignals()
+
+	return sh, nil
+}
+
+func (sh *signalHandler) continuouslyHandleSignals() {
+	defer close(sh.chDone)
+
+	for {
+		select {
+		case <-sh.chClose:
+			return
+		case sig := <-sh.chSignals:
+			sh.handleSignal(sig)
+		}
+	}
+}
+
+func (sh *signalHandler) handleSignal(sig os.Signal) {
+	var err error
+	switch sig {
+	case sh.verboseSignal:
+		err = stepGlobalLogLevel(-1)
+	case sh.quietSignal:
+		err = stepGlobalLogLevel(1)
+	case sh.reloadSignal:
+		err = sh.reloadProfile()
+	default:
+		return
+	}
+
+	if err != nil {
+		log.Error("cannot change the logger profile on signal", "signal", sig.String(), "error", err.Error())
+		return
+	}
+
+	log.Warn("logger profile changed on signal", "signal", sig.String(), "pattern", logger.GetLogLevelPattern())
+}
+
+// stepGlobalLogLevel changes the level of the "*" rules of the current log level pattern by the provided number
+// of steps, between TRACE and ERROR. A "*" rule is added in front of the pattern if it has none.
+func stepGlobalLogLevel(steps int) error {
+	pattern := logger.GetLogLevelPattern()
+	levels, patterns, err := logger.ParseLogLevelAndMatchingString(pattern)
+	if err != nil {
+		return err
+	}
+
+	rules := strings.Split(pattern, ",")
+	hasGlobalRule := false
+	for i := range rules {
+		if patterns[i] != "*" {
+			continue
+		}
+
+		hasGlobalRule = true
+		rules[i] = "*:" + strings.TrimSpace(stepLogLevel(levels[i], steps).String())
+	}
+	if !hasGlobalRule {
+		globalRule := "*:" + strings.TrimSpace(stepLogLevel(logger.LogInfo, steps).String())
+		rules = append([]string{globalRule}, rules...)
+	}
+
+	err = logger.SetLogLevel(strings.Join(rules, ","))
+	if err != nil {
+		return err
+	}
+
+	logger.NotifyProfileChange()
+	return nil
+}
+
+// stepLogLevel moves the provided level by the provided number of built-in levels, between TRACE and ERROR.
+// A custom level counts as a step towards the closest built-in level in the stepping direction.
+func stepLogLevel(level logger.LogLevel, steps int) logger.LogLevel {
+	index := 0
+	for i, steppedLevel := range steppedLogLevels {
+		if level.IsAtLeast(steppedLevel) {
+			index = i
+		}
+	}
+	if steppedLogLevels[index] != level && steps < 0 {
+		index++
+	}
+
+	index += steps
+	if index < 0 {
+		return steppedLogLevels[0]
+	}
+	if index >= len(steppedLogLevels) {
+		return steppedLogLevels[len(steppedLogLevels)-1]
+	}
+
+	return steppedLogLevels[index]
+}
+
+func (sh *signalHandler) reloadProfile() error {
+	data, err := ioutil.ReadFile(sh.profilePath)
+	if err != nil {
+		return err
+	}
+
+	err = applyProfile(data)
+	if err != nil {
+		return err
+	}
+
+	logger.NotifyProfileChange()
+	return nil
+}
+
+// Uninstall stops handling the signals, restoring their default behavior
+func (sh *signalHandler) Uninstall() {
+	sh.mutUninstall.Lock()
+	defer sh.mutUninstall.Unlock()
+
+	if sh.isUninstalled {
+		return
+	}
+	sh.isUninstalled = true
+
+	signal.Stop(sh.chSignals)
+	close(sh.chClose)
+	<-sh.chDone
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sh *signalHandler) IsInterfaceNil() bool {
+	return sh == nil
+}
@@ -0,0 +1,72 @@
+//go:build !windows
+// +build !windows
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/stretchr/testify/require"
+)
+
+func sendSignalAndWaitForPattern(t *testing.T, sig syscall.Signal, pattern string) {
+	require.Nil(t, syscall.Kill(os.Getpid(), sig))
+	require.Eventually(t, func() bool {
+		return logger.GetLogLevelPattern() == pattern
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestStepLogLevel(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, logger.LogDebug, stepLogLevel(logger.LogInfo, -1))
+	require.Equal(t, logger.LogTrace, stepLogLevel(logger.LogTrace, -1))
+	require.Equal(t, logger.LogError, stepLogLevel(logger.LogError, 1))
+	require.Equal(t, logger.LogError, stepLogLevel(logger.LogNone, 1))
+
+	// the custom levels step towards the closest built-in level
+	notice := logger.LogLevel(125)
+	require.Nil(t, logger.RegisterLogLevel("SIGNOTICE", notice, logger.LogInfo.Priority()+5, ""))
+	defer logger.UnregisterLogLevel(notice)
+	require.Equal(t, logger.LogWarning, stepLogLevel(notice, 1))
+	require.Equal(t, logger.LogInfo, stepLogLevel(notice, -1))
+	require.Equal(t, logger.LogDebug, stepLogLevel(notice, -2))
+	require.Equal(t, logger.LogError, stepLogLevel(logger.LogFatal, -1))
+}
+
+func TestSignalHandler_ShouldStepTheGlobalLogLevelAndReload(t *testing.T) {
+	defer restoreLoggingSetup()()
+
+	profilePath := filepath.Join(t.TempDir(), "profile.json")
+	require.Nil(t, ioutil.WriteFile(profilePath, []byte(`{"LogLevelPatterns": "*:ERROR,p2p:DEBUG"}`), 0600))
+
+	sh, err := InstallSignalHandler(profilePath)
+	require.Nil(t, err)
+	defer sh.Uninstall()
+
+	counter := &profileChangeCounter{}
+	logger.SubscribeToProfileChange(counter)
+	defer logger.UnsubscribeFromProfileChange(counter)
+
+	require.Nil(t, logger.SetLogLevel("p2p:DEBUG,*:INFO"))
+	sendSignalAndWaitForPattern(t, syscall.SIGUSR1, "p2p:DEBUG,*:DEBUG")
+	sendSignalAndWaitForPattern(t, syscall.SIGUSR2, "p2p:DEBUG,*:INFO")
+	sendSignalAndWaitForPattern(t, syscall.SIGUSR2, "p2p:DEBUG,*:WARN")
+
+	require.Nil(t, logger.SetLogLevel("p2p:DEBUG"))
+	sendSignalAndWaitForPattern(t, syscall.SIGUSR1, "*:DEBUG,p2p:DEBUG")
+
+	sendSignalAndWaitForPattern(t, syscall.SIGHUP, "*:ERROR,p2p:DEBUG")
+	require.Eventually(t, func() bool {
+		return counter.get() == 5
+	}, time.Second, 5*time.Millisecond)
+
+	sh.Uninstall()
+	sh.Uninstall()
+}
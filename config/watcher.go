@@ -0,0 +1,287 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+)
+
+var log = logger.GetOrCreate("logger/config")
+
+// eventsSettleDuration is the time waited after a file system event before reading the file, so that
+// the bursts of events generated by a single save result in a single reload
+const eventsSettleDuration = 50 * time.Millisecond
+
+// fileWatcher monitors a file and applies its content each time it changes. On Linux, the changes are detected
+// with inotify (watching the events of the file's name in its directory, so that atomic replacements are caught),
+// while on the other platforms, or if inotify is not available, the file's modification time and size are polled.
+type fileWatcher struct {
+	path         string
+	pollInterval time.Duration
+	applyHandler func(data []byte) error
+
+	last fileVersion
+
+	chClose  chan struct{}
+	chDone   chan struct{}
+	mutClose sync.Mutex
+	closed   bool
+}
+
+// fileVersion identifies a content of the watched file
+type fileVersion struct {
+	digest  []byte
+	modTime time.Time
+	size    int64
+}
+
+// NewConfigWatcher applies the logging configuration file (see LoadAndApplyConfig) and then monitors it,
+// applying it again on each change and notifying the profile change observers. An invalid file is reported
+// as an error log line and the previous configuration is kept. The poll interval is used when the file
+// system events are not available.
+func NewConfigWatcher(path string, pollInterval time.Duration) (*fileWatcher, error) {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	applyHandler := func(data []byte) error {
+		cfg, errParse := ParseConfig(data, format)
+		if errParse != nil {
+			return errParse
+		}
+
+		return ApplyConfig(cfg)
+	}
+
+	return newFileWatcher(path, pollInterval, applyHandler, true)
+}
+
+// NewProfileWatcher applies the marshalized profile file (see logger.Profile.Marshal) and then monitors it,
+// applying it again on each change and notifying the profile change observers. An invalid file is reported
+// as an error log line and the previous profile is kept. The poll interval is used when the file
+// system events are not available.
+func NewProfileWatcher(path string, pollInterval time.Duration) (*fileWatcher, error) {
+	return newFileWatcher(path, pollInterval, applyProfile, true)
+}
+
+func applyProfile(data []byte) error {
+	profile, err := logger.UnmarshalProfile(data)
+	if err != nil {
+		return err
+	}
+
+	return profile.Apply()
+}
+
+func newFileWatcher(
+	path string,
+	pollInterval time.Duration,
+	applyHandler func(data []byte) error,
+	useFileSystemEvents bool,
+) (*fileWatcher, error) {
+	if len(path) == 0 {
+		return nil, ErrEmptyPath
+	}
+	if pollInterval <= 0 {
+		return nil, ErrInvalidPollInterval
+	}
+
+	fw := &fileWatcher{
+		path:         path,
+		pollInterval: pollInterval,
+		applyHandler: applyHandler,
+		chClose:      make(chan struct{}),
+		chDone:       make(chan struct{}),
+	}
+
+	data, version, err := fw.readIfChanged()
+	if err != nil {
+		return nil, err
+	}
+	err = fw.apply(data, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var events *fileSystemEvents
+	if useFileSystemEvents {
+		events, err = newFileSystemEvents(path)
+		if err != nil {
+			log.Debug("file system events not available, falling back to polling",
+				"file", path, "error", err.Error())
+		}
+	}
+
+	if events != nil {
+		go fw.watchEvents(events)
+	} else {
+		go fw.poll()
+	}
+
+	return fw, nil
+}
+
+func (fw *fileWatcher) watchEvents(events *fileSystemEvents) {
+	defer close(fw.chDone)
+	defer events.close()
+
+	for {
+		select {
+		case <-fw.chClose:
+			return
+		case _, ok := <-events.chEvents:
+			if !ok {
+				log.Warn("file system events stopped, falling back to polling", "file", fw.path)
+				fw.continuouslyPoll()
+				return
+			}
+		}
+
+		if !fw.waitEventsToSettle(events) {
+			return
+		}
+		fw.checkForChanges(true)
+	}
+}
+
+// waitEventsToSettle waits until no event has been received for eventsSettleDuration. Returns false on close.
+func (fw *fileWatcher) waitEventsToSettle(events *fileSystemEvents) bool {
+	timer := time.NewTimer(eventsSettleDuration)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-fw.chClose:
+			return false
+		case _, ok := <-events.chEvents:
+			if !ok {
+				return true
+			}
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(eventsSettleDuration)
+		case <-timer.C:
+			return true
+		}
+	}
+}
+
+func (fw *fileWatcher) poll() {
+	defer close(fw.chDone)
+
+	fw.continuouslyPoll()
+}
+
+func (fw *fileWatcher) continuouslyPoll() {
+	ticker := time.NewTicker(fw.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fw.chClose:
+			return
+		case <-ticker.C:
+			fw.checkForChanges(false)
+		}
+	}
+}
+
+func (fw *fileWatcher) checkForChanges(force bool) {
+	if !force && !fw.isModified() {
+		return
+	}
+
+	data, version, err := fw.readIfChanged()
+	if err != nil {
+		log.Error("cannot read the watched logging file, keeping the previous configuration",
+			"file", fw.path, "error", err.Error())
+		return
+	}
+	if data == nil {
+		return
+	}
+
+	err = fw.apply(data, version)
+	if err != nil {
+		log.Error("invalid logging file, keeping the previous configuration",
+			"file", fw.path, "error", err.Error())
+		return
+	}
+
+	log.Info("logging file applied", "file", fw.path)
+}
+
+func (fw *fileWatcher) isModified() bool {
+	info, err := os.Stat(fw.path)
+	if err != nil {
+		return false
+	}
+
+	return !info.ModTime().Equal(fw.last.modTime) || info.Size() != fw.last.size
+}
+
+// readIfChanged returns the file's content and version if the content differs from the last applied one, nil
+// otherwise. The version is recorded once the content is applied, so that a content failing to apply is applied
+// again on the next check.
+func (fw *fileWatcher) readIfChanged() ([]byte, fileVersion, error) {
+	info, err := os.Stat(fw.path)
+	if err != nil {
+		return nil, fileVersion{}, err
+	}
+	data, err := ioutil.ReadFile(fw.path)
+	if err != nil {
+		return nil, fileVersion{}, err
+	}
+
+	digest := sha256.Sum256(data)
+	version := fileVersion{
+		digest:  digest[:],
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}
+	if bytes.Equal(version.digest, fw.last.digest) {
+		// only touched, the content is already applied
+		fw.last = version
+		return nil, version, nil
+	}
+
+	return data, version, nil
+}
+
+func (fw *fileWatcher) apply(data []byte, version fileVersion) error {
+	err := fw.applyHandler(data)
+	if err != nil {
+		return err
+	}
+	fw.last = version
+
+	logger.NotifyProfileChange()
+	return nil
+}
+
+// Close stops monitoring the file
+func (fw *fileWatcher) Close() error {
+	fw.mutClose.Lock()
+	defer fw.mutClose.Unlock()
+
+	if fw.closed {
+		return nil
+	}
+	fw.closed = true
+	close(fw.chClose)
+	<-fw.chDone
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fw *fileWatcher) IsInterfaceNil() bool {
+	return fw == nil
+}
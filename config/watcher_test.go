@@ -0,0 +1,150 @@
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/stretchr/testify/require"
+)
+
+type profileChangeCounter struct {
+	numCalls int32
+}
+
+func (counter *profileChangeCounter) OnProfileChanged() {
+	atomic.AddInt32(&counter.numCalls, 1)
+}
+
+func (counter *profileChangeCounter) get() int32 {
+	return atomic.LoadInt32(&counter.numCalls)
+}
+
+func writeProfileFile(t *testing.T, path string, profile logger.Profile) {
+	data, err := profile.Marshal()
+	require.Nil(t, err)
+
+	// the file is replaced atomically, as the configuration management tools usually do
+	tmpPath := path + ".tmp"
+	require.Nil(t, ioutil.WriteFile(tmpPath, data, 0600))
+	require.Nil(t, os.Rename(tmpPath, path))
+}
+
+func TestNewProfileWatcher_InvalidArgumentsShouldErr(t *testing.T) {
+	fw, err := NewProfileWatcher("", time.Second)
+	require.True(t, fw.IsInterfaceNil())
+	require.Equal(t, ErrEmptyPath, err)
+
+	fw, err = NewProfileWatcher("profile.json", 0)
+	require.True(t, fw.IsInterfaceNil())
+	require.Equal(t, ErrInvalidPollInterval, err)
+
+	fw, err = NewProfileWatcher(filepath.Join(t.TempDir(), "missing.json"), time.Second)
+	require.True(t, fw.IsInterfaceNil())
+	require.True(t, os.IsNotExist(err))
+
+	path := filepath.Join(t.TempDir(), "profile.json")
+	require.Nil(t, ioutil.WriteFile(path, []byte(`{"LogLevelPatterns": "*:FOO"}`), 0600))
+	fw, err = NewProfileWatcher(path, time.Second)
+	require.True(t, fw.IsInterfaceNil())
+	require.NotNil(t, err)
+}
+
+func testProfileWatcherShouldReloadOnChanges(t *testing.T, useFileSystemEvents bool) {
+	defer restoreLoggingSetup()()
+
+	counter := &profileChangeCounter{}
+	logger.SubscribeToProfileChange(counter)
+	defer logger.UnsubscribeFromProfileChange(counter)
+
+	path := filepath.Join(t.TempDir(), "profile.json")
+	writeProfileFile(t, path, logger.Profile{LogLevelPatterns: "*:DEBUG"})
+
+	fw, err := newFileWatcher(path, 10*time.Millisecond, applyProfile, useFileSystemEvents)
+	require.Nil(t, err)
+	defer func() {
+		_ = fw.Close()
+	}()
+	require.Equal(t, "*:DEBUG", logger.GetLogLevelPattern())
+
+	writeProfileFile(t, path, logger.Profile{LogLevelPatterns: "*:TRACE", WithLoggerName: true})
+	require.Eventually(t, func() bool {
+		return logger.GetLogLevelPattern() == "*:TRACE"
+	}, 2*time.Second, 10*time.Millisecond)
+	require.True(t, logger.GetCurrentProfile().WithLoggerName)
+	require.Eventually(t, func() bool {
+		return counter.get() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// an invalid file is reported and the previous profile is kept
+	require.Nil(t, ioutil.WriteFile(path, []byte(`{"LogLevelPatterns": "*:FOO", "WithLoggerName": false}`), 0600))
+	time.Sleep(200 * time.Millisecond)
+	require.Equal(t, "*:TRACE", logger.GetLogLevelPattern())
+	require.True(t, logger.GetCurrentProfile().WithLoggerName)
+	require.Equal(t, int32(2), counter.get())
+
+	writeProfileFile(t, path, logger.Profile{LogLevelPatterns: "*:WARN"})
+	require.Eventually(t, func() bool {
+		return logger.GetLogLevelPattern() == "*:WARN"
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestProfileWatcher_FileSystemEventsShouldReloadOnChanges(t *testing.T) {
+	testProfileWatcherShouldReloadOnChanges(t, true)
+}
+
+func TestProfileWatcher_PollingShouldReloadOnChanges(t *testing.T) {
+	testProfileWatcherShouldReloadOnChanges(t, false)
+}
+
+func TestFileWatcher_FailedApplyShouldBeRetried(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profile.json")
+	require.Nil(t, ioutil.WriteFile(path, []byte("first"), 0600))
+
+	var numCalls int32
+	var applied atomic.Value
+	applyHandler := func(data []byte) error {
+		// the second content fails to apply once, as if a connection of the configuration could not be opened
+		if atomic.AddInt32(&numCalls, 1) == 2 {
+			return errors.New("transient error")
+		}
+		applied.Store(string(data))
+		return nil
+	}
+
+	fw, err := newFileWatcher(path, 10*time.Millisecond, applyHandler, false)
+	require.Nil(t, err)
+	defer func() {
+		_ = fw.Close()
+	}()
+
+	require.Nil(t, ioutil.WriteFile(path, []byte("second"), 0600))
+	require.Eventually(t, func() bool {
+		return applied.Load() == "second"
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(3), atomic.LoadInt32(&numCalls))
+}
+
+func TestConfigWatcher_ShouldReloadOnChanges(t *testing.T) {
+	defer restoreLoggingSetup()()
+
+	path := filepath.Join(t.TempDir(), "logging.toml")
+	require.Nil(t, ioutil.WriteFile(path, []byte("[Profile]\nLogLevelPatterns = \"*:ERROR\"\n"), 0600))
+
+	fw, err := NewConfigWatcher(path, 10*time.Millisecond)
+	require.Nil(t, err)
+	require.Equal(t, "*:ERROR", logger.GetLogLevelPattern())
+
+	require.Nil(t, ioutil.WriteFile(path, []byte("[Profile]\nLogLevelPatterns = \"*:DEBUG\"\n"), 0600))
+	require.Eventually(t, func() bool {
+		return logger.GetLogLevelPattern() == "*:DEBUG"
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.Nil(t, fw.Close())
+	require.Nil(t, fw.Close())
+}
@@ -9,6 +9,8 @@ const (
 	ansiRegularYellow    = "0;33m"
 	ansiRegularRed       = "0;31m"
 	ansiRegularBlack     = "0;30m"
+	ansiRegularMagenta   = "0;35m"
+	ansiBoldRed          = "1;31m"
 )
 
 // ConsoleFormatter implements formatter interface and is used to format log lines to be written on the console
@@ -38,12 +40,15 @@ func (cf *ConsoleFormatter) Output(line LogLineHandler) []byte {
 		correlation = formatCorrelationElements(line.GetCorrelation())
 	}
 
+	details, stack := formatDetails(line)
+	correlation = appendElements(correlation, details)
+
 	return []byte(
 		fmt.Sprintf(formatColoredString,
 			levelColor, level,
 			timestamp, loggerName, correlation,
 			message, args,
-		),
+		) + stack,
 	)
 }
 
@@ -76,9 +81,18 @@ func getLevelColor(level LogLevel) string {
 		return ansiRegularYellow
 	case LogError:
 		return ansiRegularRed
-	default:
-		return ansiRegularBlack
+	case LogPanic:
+		return ansiRegularMagenta
+	case LogFatal:
+		return ansiBoldRed
+	}
+
+	color, ok := getCustomLevelColor(level)
+	if ok {
+		return color
 	}
+
+	return ansiRegularBlack
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
@@ -0,0 +1,130 @@
+package control
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/pipes"
+)
+
+// client sends control requests to a running process, through its control Unix domain socket
+type client struct {
+	mut       sync.Mutex
+	conn      net.Conn
+	messenger *pipes.Messenger
+}
+
+// NewClient connects to the control socket found at the provided path
+func NewClient(socketPath string) (*client, error) {
+	if len(socketPath) == 0 {
+		return nil, ErrEmptySocketPath
+	}
+
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{
+		conn:      conn,
+		messenger: pipes.NewMessenger(conn, conn),
+	}, nil
+}
+
+// SetLogLevel sets the log level pattern of the remote process. A positive duration makes the change a temporary
+// override. It returns the base pattern and the active overrides after the change.
+func (c *client) SetLogLevel(pattern string, duration time.Duration) (string, []logger.LogLevelOverride, error) {
+	response, err := c.send(Request{Command: CommandSetLogLevel, Pattern: pattern, Duration: duration})
+	return response.Pattern, response.Overrides, err
+}
+
+// GetLogLevelPattern returns the base log level pattern and the active overrides of the remote process
+func (c *client) GetLogLevelPattern() (string, []logger.LogLevelOverride, error) {
+	response, err := c.send(Request{Command: CommandGetLogLevelPattern})
+	return response.Pattern, response.Overrides, err
+}
+
+// GetLoggerLogLevel returns the log level of a logger of the remote process
+func (c *client) GetLoggerLogLevel(loggerName string) (logger.LogLevel, error) {
+	response, err := c.send(Request{Command: CommandGetLoggerLogLevel, LoggerName: loggerName})
+	if err != nil {
+		return logger.LogNone, err
+	}
+
+	return logger.GetLogLevel(response.LogLevel)
+}
+
+// GetLoggers returns the loggers (and their log levels) of the remote process
+func (c *client) GetLoggers() ([]LoggerInfo, error) {
+	response, err := c.send(Request{Command: CommandGetLoggers})
+	return response.Loggers, err
+}
+
+// ToggleCorrelation enables or disables the correlation elements in the logs of the remote process
+func (c *client) ToggleCorrelation(enable bool) (logger.Profile, error) {
+	response, err := c.send(Request{Command: CommandToggleCorrelation, Enable: enable})
+	return response.Profile, err
+}
+
+// ToggleLoggerName enables or disables the logger names in the logs of the remote process
+func (c *client) ToggleLoggerName(enable bool) (logger.Profile, error) {
+	response, err := c.send(Request{Command: CommandToggleLoggerName, Enable: enable})
+	return response.Profile, err
+}
+
+// GetProfile returns a snapshot of the remote process' logger profile
+func (c *client) GetProfile() (logger.Profile, error) {
+	response, err := c.send(Request{Command: CommandGetProfile})
+	return response.Profile, err
+}
+
+// ApplyProfile applies the provided profile on the remote process
+func (c *client) ApplyProfile(profile logger.Profile) (logger.Profile, error) {
+	response, err := c.send(Request{Command: CommandApplyProfile, Profile: profile})
+	return response.Profile, err
+}
+
+func (c *client) send(request Request) (Response, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return Response{}, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, err = c.messenger.SendMessage(data)
+	if err != nil {
+		return Response{}, err
+	}
+
+	buffer, err := c.messenger.ReadMessage()
+	if err != nil {
+		return Response{}, err
+	}
+
+	response := Response{}
+	err = json.Unmarshal(buffer, &response)
+	if err != nil {
+		return Response{}, err
+	}
+	if len(response.Error) > 0 {
+		return response, errors.New(response.Error)
+	}
+
+	return response, nil
+}
+
+// Close closes the connection to the control socket
+func (c *client) Close() error {
+	return c.conn.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (c *client) IsInterfaceNil() bool {
+	return c == nil
+}
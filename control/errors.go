@@ -0,0 +1,37 @@
+package control
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrEmptySocketPath signals that an empty socket path has been provided
+var ErrEmptySocketPath = errors.New("empty socket path")
+
+// ErrUnknownCommand signals that an unknown command has been received
+var ErrUnknownCommand = errors.New("unknown command")
+
+// ErrInvalidDuration signals that an invalid duration has been provided
+var ErrInvalidDuration = errors.New("invalid duration")
+
+// ErrServerClosed signals that the control server has been closed
+var ErrServerClosed = errors.New("control server closed")
+
+// ErrSocketInUse signals that the socket path is used by a live control server
+var ErrSocketInUse = errors.New("control socket already in use")
+
+// ErrInsecureSocketDirectory signals that the default socket directory can be accessed by other users
+var ErrInsecureSocketDirectory = errors.New("insecure control socket directory")
+
+func createErrUnknownCommand(command string) error {
+	return fmt.Errorf("%w '%s'", ErrUnknownCommand, command)
+}
+
+func createErrSocketInUse(socketPath string) error {
+	return fmt.Errorf("%w: %s", ErrSocketInUse, socketPath)
+}
+
+func createErrInsecureSocketDirectory(directory string, mode os.FileMode) error {
+	return fmt.Errorf("%w: %s has the mode %s, expected %s", ErrInsecureSocketDirectory, directory, mode, os.FileMode(socketDirectoryMode))
+}
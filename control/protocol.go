@@ -0,0 +1,67 @@
+package control
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+)
+
+// The commands understood by the control server
+const (
+	CommandSetLogLevel        = "SetLogLevel"
+	CommandGetLogLevelPattern = "GetLogLevelPattern"
+	CommandGetLoggerLogLevel  = "GetLoggerLogLevel"
+	CommandGetLoggers         = "GetLoggers"
+	CommandToggleCorrelation  = "ToggleCorrelation"
+	CommandToggleLoggerName   = "ToggleLoggerName"
+	CommandGetProfile         = "GetProfile"
+	CommandApplyProfile       = "ApplyProfile"
+)
+
+const socketFileExtension = ".sock"
+
+// Request is a command sent by a control client. Only the fields relevant to the command have to be set.
+type Request struct {
+	Command    string
+	Pattern    string
+	Duration   time.Duration
+	LoggerName string
+	Enable     bool
+	Profile    logger.Profile
+}
+
+// Response is the control server's answer to a Request. A non-empty Error signals a failed command.
+type Response struct {
+	Error     string
+	Pattern   string
+	Overrides []logger.LogLevelOverride
+	LogLevel  string
+	Loggers   []LoggerInfo
+	Profile   logger.Profile
+}
+
+// LoggerInfo holds the name and the current log level of a logger
+type LoggerInfo struct {
+	Name     string
+	LogLevel string
+}
+
+// SocketDirectory returns the private directory holding the default control sockets of the current user's processes
+func SocketDirectory() string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("dme-logger-%d", os.Getuid()))
+}
+
+// DefaultSocketPath returns the default path of the control Unix domain socket of the current process, so that the
+// processes running on the same host do not share it
+func DefaultSocketPath() string {
+	return SocketPathOfProcess(os.Getpid())
+}
+
+// SocketPathOfProcess returns the default path of the control Unix domain socket of the process having the provided pid
+func SocketPathOfProcess(pid int) string {
+	return filepath.Join(SocketDirectory(), strconv.Itoa(pid)+socketFileExtension)
+}
@@ -0,0 +1,337 @@
+package control
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/pipes"
+)
+
+var log = logger.GetOrCreate("control/server")
+
+const socketFileMode = 0600
+const socketDirectoryMode = 0700
+const staleSocketDialTimeout = time.Second
+
+// server exposes the global logger settings on a Unix domain socket. Each request and response is a JSON
+// message, framed in the same manner as pipes.Messenger does (length prefix).
+type server struct {
+	socketPath string
+	listener   net.Listener
+
+	mutConnections sync.Mutex
+	connections    map[net.Conn]struct{}
+	closed         bool
+}
+
+// StartServer starts listening for control requests on the provided Unix domain socket path, such as the one
+// returned by DefaultSocketPath. A stale socket file left by a previous run is removed, while a socket a live process
+// still listens on is left alone and ErrSocketInUse is returned. Only the current user can connect to the socket.
+func StartServer(socketPath string) (*server, error) {
+	if len(socketPath) == 0 {
+		return nil, ErrEmptySocketPath
+	}
+
+	err := prepareSocketDirectory(filepath.Dir(socketPath))
+	if err != nil {
+		return nil, err
+	}
+
+	err = removeStaleSocket(socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := listenPrivately(socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &server{
+		socketPath:  socketPath,
+		listener:    listener,
+		connections: make(map[net.Conn]struct{}),
+	}
+
+	go s.continuouslyAccept()
+
+	return s, nil
+}
+
+// prepareSocketDirectory creates the default socket directory, private to the current user, and checks that no other
+// user can access it. The other directories are left as they are.
+func prepareSocketDirectory(directory string) error {
+	if directory != SocketDirectory() {
+		return nil
+	}
+
+	err := os.MkdirAll(directory, socketDirectoryMode)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(directory)
+	if err != nil {
+		return err
+	}
+	if info.Mode().Perm()&^socketDirectoryMode != 0 {
+		return createErrInsecureSocketDirectory(directory, info.Mode().Perm())
+	}
+
+	return nil
+}
+
+// removeStaleSocket removes the socket file left by a process that no longer listens on it. A socket accepting
+// connections belongs to a live process, so it is not taken over.
+func removeStaleSocket(socketPath string) error {
+	info, err := os.Lstat(socketPath)
+	if err != nil {
+		return nil
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s: %w", socketPath, syscall.EADDRINUSE)
+	}
+
+	conn, err := net.DialTimeout("unix", socketPath, staleSocketDialTimeout)
+	if err == nil {
+		_ = conn.Close()
+		return createErrSocketInUse(socketPath)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return err
+	}
+
+	return os.Remove(socketPath)
+}
+
+// listenPrivately creates the socket in a temporary directory private to the current user, restricts its mode and
+// only then moves it to the provided path, so that the other users never get the chance to connect to it
+func listenPrivately(socketPath string) (net.Listener, error) {
+	privateDirectory, err := os.MkdirTemp(filepath.Dir(socketPath), ".ctl")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = os.RemoveAll(privateDirectory)
+	}()
+
+	privatePath := filepath.Join(privateDirectory, "s")
+	listener, err := net.Listen("unix", privatePath)
+	if err != nil {
+		return nil, err
+	}
+	// the socket file is moved, Close removes it from its final path
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	err = os.Chmod(privatePath, socketFileMode)
+	if err == nil {
+		err = os.Rename(privatePath, socketPath)
+	}
+	if err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+func (s *server) continuouslyAccept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			log.Debug("control server stopped accepting connections", "err", err)
+			return
+		}
+
+		if !s.trackConnection(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go s.serveConnection(conn)
+	}
+}
+
+func (s *server) trackConnection(conn net.Conn) bool {
+	s.mutConnections.Lock()
+	defer s.mutConnections.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	s.connections[conn] = struct{}{}
+	return true
+}
+
+func (s *server) untrackConnection(conn net.Conn) {
+	s.mutConnections.Lock()
+	delete(s.connections, conn)
+	s.mutConnections.Unlock()
+}
+
+func (s *server) serveConnection(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+		s.untrackConnection(conn)
+	}()
+
+	messenger := pipes.NewMessenger(conn, conn)
+	for {
+		buffer, err := messenger.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		response := s.handleMessage(buffer)
+		data, err := json.Marshal(response)
+		if err != nil {
+			return
+		}
+
+		_, err = messenger.SendMessage(data)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *server) handleMessage(buffer []byte) Response {
+	request := Request{}
+	err := json.Unmarshal(buffer, &request)
+	if err != nil {
+		return Response{Error: err.Error()}
+	}
+
+	response, err := s.handleRequest(request)
+	if err != nil {
+		return Response{Error: err.Error()}
+	}
+
+	return response
+}
+
+func (s *server) handleRequest(request Request) (Response, error) {
+	switch request.Command {
+	case CommandSetLogLevel:
+		err := s.setLogLevel(request.Pattern, request.Duration)
+		if err == nil && request.Duration == 0 {
+			logger.NotifyProfileChange()
+		}
+		return getLogLevelPatternResponse(), err
+	case CommandGetLogLevelPattern:
+		return getLogLevelPatternResponse(), nil
+	case CommandGetLoggerLogLevel:
+		return Response{LogLevel: trimmedLevel(logger.GetLoggerLogLevel(request.LoggerName))}, nil
+	case CommandGetLoggers:
+		return Response{Loggers: getLoggers()}, nil
+	case CommandToggleCorrelation:
+		logger.ToggleCorrelation(request.Enable)
+		logger.NotifyProfileChange()
+		return Response{Profile: logger.GetCurrentProfile()}, nil
+	case CommandToggleLoggerName:
+		logger.ToggleLoggerName(request.Enable)
+		logger.NotifyProfileChange()
+		return Response{Profile: logger.GetCurrentProfile()}, nil
+	case CommandGetProfile:
+		return Response{Profile: logger.GetCurrentProfile()}, nil
+	case CommandApplyProfile:
+		err := s.applyProfile(request.Profile)
+		return Response{Profile: logger.GetCurrentProfile()}, err
+	default:
+		return Response{}, createErrUnknownCommand(request.Command)
+	}
+}
+
+// setLogLevel applies the provided pattern. When a positive duration is provided, the pattern is applied as
+// a temporary override (see logger.SetLogLevelFor)
+func (s *server) setLogLevel(pattern string, duration time.Duration) error {
+	var err error
+	switch {
+	case duration < 0:
+		return ErrInvalidDuration
+	case duration == 0:
+		err = logger.SetLogLevel(pattern)
+	default:
+		err = logger.SetLogLevelFor(pattern, duration)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Info("log level pattern changed through the control socket", "pattern", pattern, "for", duration)
+
+	return nil
+}
+
+func (s *server) applyProfile(profile logger.Profile) error {
+	err := profile.Apply()
+	if err != nil {
+		return err
+	}
+
+	log.Info("profile applied through the control socket", "profile", profile.String())
+	logger.NotifyProfileChange()
+
+	return nil
+}
+
+func getLogLevelPatternResponse() Response {
+	return Response{
+		Pattern:   logger.GetLogLevelPattern(),
+		Overrides: logger.GetLogLevelOverrides(),
+	}
+}
+
+func getLoggers() []LoggerInfo {
+	names := logger.GetLoggerNames()
+	infos := make([]LoggerInfo, 0, len(names))
+	for _, name := range names {
+		infos = append(infos, LoggerInfo{
+			Name:     name,
+			LogLevel: trimmedLevel(logger.GetLoggerLogLevel(name)),
+		})
+	}
+
+	return infos
+}
+
+func trimmedLevel(level logger.LogLevel) string {
+	return strings.TrimSpace(level.String())
+}
+
+// Close stops the server, closes all the client connections and removes the socket file
+func (s *server) Close() error {
+	s.mutConnections.Lock()
+	if s.closed {
+		s.mutConnections.Unlock()
+		return ErrServerClosed
+	}
+	s.closed = true
+	for conn := range s.connections {
+		_ = conn.Close()
+	}
+	s.mutConnections.Unlock()
+
+	err := s.listener.Close()
+	_ = os.Remove(s.socketPath)
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *server) IsInterfaceNil() bool {
+	return s == nil
+}
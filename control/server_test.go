@@ -0,0 +1,225 @@
+package control
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/stretchr/testify/require"
+)
+
+type profileChangeObserverStub struct {
+	numCalls chan struct{}
+}
+
+func (stub *profileChangeObserverStub) OnProfileChanged() {
+	stub.numCalls <- struct{}{}
+}
+
+func startServerAndClient(t *testing.T) (*server, *client) {
+	socketPath := filepath.Join(t.TempDir(), "control.sock")
+
+	s, err := StartServer(socketPath)
+	require.Nil(t, err)
+
+	c, err := NewClient(socketPath)
+	require.Nil(t, err)
+
+	return s, c
+}
+
+func TestStartServer_EmptySocketPathShouldErr(t *testing.T) {
+	s, err := StartServer("")
+	require.Nil(t, s)
+	require.Equal(t, ErrEmptySocketPath, err)
+}
+
+func TestStartServer_LiveSocketShouldNotBeTakenOver(t *testing.T) {
+	s, c := startServerAndClient(t)
+	defer func() {
+		_ = c.Close()
+		_ = s.Close()
+	}()
+
+	other, err := StartServer(s.socketPath)
+	require.Nil(t, other)
+	require.True(t, errors.Is(err, ErrSocketInUse))
+
+	_, _, err = c.GetLogLevelPattern()
+	require.Nil(t, err)
+}
+
+func TestStartServer_StaleSocketShouldBeReplaced(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "control.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.Nil(t, err)
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = listener.Close()
+
+	s, err := StartServer(socketPath)
+	require.Nil(t, err)
+	defer func() {
+		_ = s.Close()
+	}()
+
+	info, err := os.Stat(socketPath)
+	require.Nil(t, err)
+	require.Equal(t, os.FileMode(socketFileMode), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(socketPath))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(entries))
+}
+
+func TestStartServer_RegularFileShouldNotBeReplaced(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "control.sock")
+	require.Nil(t, os.WriteFile(socketPath, []byte("data"), 0600))
+
+	s, err := StartServer(socketPath)
+	require.Nil(t, s)
+	require.NotNil(t, err)
+
+	data, err := os.ReadFile(socketPath)
+	require.Nil(t, err)
+	require.Equal(t, "data", string(data))
+}
+
+func TestDefaultSocketPath_ShouldBePerProcess(t *testing.T) {
+	socketPath := DefaultSocketPath()
+	require.Equal(t, SocketDirectory(), filepath.Dir(socketPath))
+	require.True(t, strings.HasPrefix(filepath.Base(socketPath), strconv.Itoa(os.Getpid())+"."))
+
+	s, err := StartServer(socketPath)
+	require.Nil(t, err)
+	defer func() {
+		_ = s.Close()
+	}()
+
+	info, err := os.Stat(SocketDirectory())
+	require.Nil(t, err)
+	require.Equal(t, os.FileMode(socketDirectoryMode), info.Mode().Perm())
+}
+
+func TestServer_SetAndGetLogLevel(t *testing.T) {
+	s, c := startServerAndClient(t)
+	defer func() {
+		_ = c.Close()
+		_ = s.Close()
+	}()
+
+	_ = logger.GetOrCreate("control/foo")
+	observer := &profileChangeObserverStub{numCalls: make(chan struct{}, 10)}
+	logger.SubscribeToProfileChange(observer)
+	defer logger.UnsubscribeFromProfileChange(observer)
+
+	pattern, overrides, err := c.SetLogLevel("*:INFO,control/foo:TRACE", 0)
+	require.Nil(t, err)
+	require.Empty(t, overrides)
+	require.Equal(t, "*:INFO,control/foo:TRACE", pattern)
+	require.Eventually(t, func() bool {
+		return len(observer.numCalls) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	pattern, _, err = c.GetLogLevelPattern()
+	require.Nil(t, err)
+	require.Equal(t, "*:INFO,control/foo:TRACE", pattern)
+
+	level, err := c.GetLoggerLogLevel("control/foo")
+	require.Nil(t, err)
+	require.Equal(t, logger.LogTrace, level)
+
+	loggers, err := c.GetLoggers()
+	require.Nil(t, err)
+	require.Contains(t, loggers, LoggerInfo{Name: "control/foo", LogLevel: "TRACE"})
+
+	_, _, err = c.SetLogLevel("*:FOO", 0)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "unknown log level")
+	require.Equal(t, "*:INFO,control/foo:TRACE", logger.GetLogLevelPattern())
+
+	_ = logger.SetLogLevel("*:INFO")
+}
+
+func TestServer_SetLogLevelForDurationShouldOverrideTemporarily(t *testing.T) {
+	s, c := startServerAndClient(t)
+	defer func() {
+		_ = c.Close()
+		_ = s.Close()
+	}()
+
+	_ = logger.SetLogLevel("*:INFO")
+	log := logger.GetOrCreate("control/bar")
+
+	pattern, overrides, err := c.SetLogLevel("control/bar:TRACE", 100*time.Millisecond)
+	require.Nil(t, err)
+	require.Equal(t, "*:INFO", pattern)
+	require.Equal(t, 1, len(overrides))
+	require.Equal(t, "control/bar:TRACE", overrides[0].Pattern)
+	require.Equal(t, logger.LogTrace, log.GetLevel())
+
+	require.Eventually(t, func() bool {
+		return log.GetLevel() == logger.LogInfo
+	}, time.Second, 10*time.Millisecond)
+
+	_, overrides, err = c.GetLogLevelPattern()
+	require.Nil(t, err)
+	require.Empty(t, overrides)
+
+	_, _, err = c.SetLogLevel("*:TRACE", -time.Second)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidDuration.Error(), err.Error())
+}
+
+func TestServer_ProfileCommands(t *testing.T) {
+	s, c := startServerAndClient(t)
+	defer func() {
+		_ = c.Close()
+		_ = s.Close()
+	}()
+
+	profile, err := c.ToggleCorrelation(true)
+	require.Nil(t, err)
+	require.True(t, profile.WithCorrelation)
+
+	profile, err = c.ToggleLoggerName(true)
+	require.Nil(t, err)
+	require.True(t, profile.WithLoggerName)
+
+	profile, err = c.ApplyProfile(logger.Profile{LogLevelPatterns: "*:WARN"})
+	require.Nil(t, err)
+	require.Equal(t, logger.Profile{LogLevelPatterns: "*:WARN"}, profile)
+
+	profile, err = c.GetProfile()
+	require.Nil(t, err)
+	require.Equal(t, logger.GetCurrentProfile(), profile)
+
+	_ = logger.SetLogLevel("*:INFO")
+}
+
+func TestServer_UnknownCommandShouldErr(t *testing.T) {
+	s, c := startServerAndClient(t)
+	defer func() {
+		_ = c.Close()
+		_ = s.Close()
+	}()
+
+	_, err := c.send(Request{Command: "foo"})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), ErrUnknownCommand.Error())
+}
+
+func TestServer_CloseTwiceShouldErr(t *testing.T) {
+	s, c := startServerAndClient(t)
+
+	require.Nil(t, s.Close())
+	require.Equal(t, ErrServerClosed, s.Close())
+
+	_, _, err := c.GetLogLevelPattern()
+	require.NotNil(t, err)
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/kalyan3104/dme-logger-go/proto"
@@ -16,10 +17,16 @@ type logCorrelation struct {
 	epoch    uint32
 	round    int64
 	subRound string
+	elements []proto.LogCorrelationElement
 }
 
 // ToggleCorrelation enables or disables correlation elements for log lines
 func ToggleCorrelation(enable bool) {
+	toggleCorrelation(enable)
+	onProfileMutated(ProfileChangeSourceToggleCorrelation)
+}
+
+func toggleCorrelation(enable bool) {
 	globalCorrelation.mut.Lock()
 	globalCorrelation.enabled = enable
 	globalCorrelation.mut.Unlock()
@@ -62,6 +69,51 @@ func SetCorrelationSubround(subRound string) {
 	globalCorrelation.mut.Unlock()
 }
 
+// SetCorrelation sets a named log correlation element (e.g. "nonce", "peer" or "request"), besides the shard,
+// epoch, round and sub-round ones. The value is stored as its string representation (byte slices are converted
+// with DisplayByteSlice). The named elements are output in the order they have been first set.
+func SetCorrelation(key string, value interface{}) {
+	element := proto.LogCorrelationElement{
+		Key:   key,
+		Value: correlationValueToString(value),
+	}
+
+	globalCorrelation.mut.Lock()
+	defer globalCorrelation.mut.Unlock()
+
+	for i := range globalCorrelation.elements {
+		if globalCorrelation.elements[i].Key == key {
+			globalCorrelation.elements[i] = element
+			return
+		}
+	}
+	globalCorrelation.elements = append(globalCorrelation.elements, element)
+}
+
+// RemoveCorrelation removes a named log correlation element
+func RemoveCorrelation(key string) {
+	globalCorrelation.mut.Lock()
+	defer globalCorrelation.mut.Unlock()
+
+	for i := range globalCorrelation.elements {
+		if globalCorrelation.elements[i].Key == key {
+			globalCorrelation.elements = append(globalCorrelation.elements[:i], globalCorrelation.elements[i+1:]...)
+			return
+		}
+	}
+}
+
+func correlationValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return DisplayByteSlice(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 // GetCorrelation gets global correlation elements
 func GetCorrelation() proto.LogCorrelationMessage {
 	globalCorrelation.mut.RLock()
@@ -71,6 +123,10 @@ func GetCorrelation() proto.LogCorrelationMessage {
 		Round:    globalCorrelation.round,
 		SubRound: globalCorrelation.subRound,
 	}
+	if len(globalCorrelation.elements) > 0 {
+		lcm.Elements = make([]proto.LogCorrelationElement, len(globalCorrelation.elements))
+		copy(lcm.Elements, globalCorrelation.elements)
+	}
 	globalCorrelation.mut.RUnlock()
 
 	return lcm
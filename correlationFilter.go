@@ -0,0 +1,340 @@
+package logger
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kalyan3104/dme-logger-go/check"
+	"github.com/kalyan3104/dme-logger-go/proto"
+)
+
+// The names of the built-in correlation elements, as used in the correlation filters
+const (
+	CorrelationKeyShard    = "shard"
+	CorrelationKeyEpoch    = "epoch"
+	CorrelationKeyRound    = "round"
+	CorrelationKeySubRound = "subround"
+)
+
+const (
+	operatorEqual          = "="
+	operatorNotEqual       = "!="
+	operatorGreater        = ">"
+	operatorGreaterOrEqual = ">="
+	operatorLower          = "<"
+	operatorLowerOrEqual   = "<="
+	operatorIn             = "in"
+)
+
+// the two characters operators need to be checked first
+var comparisonOperators = []string{
+	operatorNotEqual,
+	operatorGreaterOrEqual,
+	operatorLowerOrEqual,
+	operatorEqual,
+	operatorGreater,
+	operatorLower,
+}
+
+var _ CorrelationFilter = (*correlationFilter)(nil)
+
+var mutCorrelationFilter = &sync.RWMutex{}
+var globalCorrelationFilter *correlationFilter
+
+type correlationCondition struct {
+	key        string
+	operator   string
+	value      string
+	number     int64
+	isNumber   bool
+	upperBound int64
+}
+
+// correlationFilter selects the log lines by their correlation elements
+type correlationFilter struct {
+	expression string
+	conditions []correlationCondition
+}
+
+// NewCorrelationFilter parses a correlation filter expression: comma-separated conditions, all of them needing
+// to hold, in the form KEY OPERATOR VALUE, with the =, !=, >, >=, <, <= operators, or KEY in [MIN,MAX]
+// (inclusive range). The keys are the built-in shard, epoch, round and subround elements or the named
+// correlation elements (see SetCorrelation). Example: "shard=1,epoch>=12,round in [100,200],subround=(BLOCK)"
+func NewCorrelationFilter(expression string) (*correlationFilter, error) {
+	parts := splitFilterExpression(expression)
+	conditions := make([]correlationCondition, 0, len(parts))
+	for _, part := range parts {
+		condition, err := parseCorrelationCondition(part)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return &correlationFilter{
+		expression: expression,
+		conditions: conditions,
+	}, nil
+}
+
+// splitFilterExpression splits the expression by the commas found outside the brackets
+func splitFilterExpression(expression string) []string {
+	parts := make([]string, 0)
+	depth := 0
+	start := 0
+	for i, c := range expression {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, expression[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, expression[start:])
+}
+
+func parseCorrelationCondition(condition string) (correlationCondition, error) {
+	condition = strings.TrimSpace(condition)
+	if len(condition) == 0 {
+		return correlationCondition{}, createErrInvalidCorrelationFilter(condition, "empty condition")
+	}
+
+	fields := strings.Fields(condition)
+	if len(fields) >= 2 && strings.ToLower(fields[1]) == operatorIn {
+		return parseRangeCondition(condition, fields[0], strings.Join(fields[2:], ""))
+	}
+
+	for _, operator := range comparisonOperators {
+		index := strings.Index(condition, operator)
+		if index < 0 {
+			continue
+		}
+
+		parsed := correlationCondition{
+			key:      normalizeCorrelationKey(condition[:index]),
+			operator: operator,
+			value:    strings.TrimSpace(condition[index+len(operator):]),
+		}
+		if len(parsed.key) == 0 || len(parsed.value) == 0 {
+			return correlationCondition{}, createErrInvalidCorrelationFilter(condition, "missing key or value")
+		}
+
+		number, err := strconv.ParseInt(parsed.value, 10, 64)
+		parsed.number, parsed.isNumber = number, err == nil
+		if !parsed.isNumber && operator != operatorEqual && operator != operatorNotEqual {
+			return correlationCondition{}, createErrInvalidCorrelationFilter(condition, "numeric value expected")
+		}
+
+		return parsed, nil
+	}
+
+	return correlationCondition{}, createErrInvalidCorrelationFilter(condition, "missing operator")
+}
+
+func parseRangeCondition(condition string, key string, bounds string) (correlationCondition, error) {
+	if !strings.HasPrefix(bounds, "[") || !strings.HasSuffix(bounds, "]") {
+		return correlationCondition{}, createErrInvalidCorrelationFilter(condition, "expected [MIN,MAX] range")
+	}
+
+	values := strings.Split(bounds[1:len(bounds)-1], ",")
+	if len(values) != 2 {
+		return correlationCondition{}, createErrInvalidCorrelationFilter(condition, "expected [MIN,MAX] range")
+	}
+
+	lower, errLower := strconv.ParseInt(values[0], 10, 64)
+	upper, errUpper := strconv.ParseInt(values[1], 10, 64)
+	if errLower != nil || errUpper != nil || lower > upper {
+		return correlationCondition{}, createErrInvalidCorrelationFilter(condition, "invalid numeric range")
+	}
+
+	return correlationCondition{
+		key:        normalizeCorrelationKey(key),
+		operator:   operatorIn,
+		number:     lower,
+		isNumber:   true,
+		upperBound: upper,
+	}, nil
+}
+
+func normalizeCorrelationKey(key string) string {
+	key = strings.TrimSpace(key)
+	switch strings.ToLower(key) {
+	case CorrelationKeyShard, CorrelationKeyEpoch, CorrelationKeyRound, CorrelationKeySubRound:
+		return strings.ToLower(key)
+	default:
+		return key
+	}
+}
+
+// Matches returns true if the provided correlation elements satisfy all the filter's conditions
+func (cf *correlationFilter) Matches(correlation proto.LogCorrelationMessage) bool {
+	for _, condition := range cf.conditions {
+		value, ok := getCorrelationValue(correlation, condition.key)
+		if !ok || !condition.matches(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getCorrelationValue(correlation proto.LogCorrelationMessage, key string) (string, bool) {
+	switch key {
+	case CorrelationKeyShard:
+		return correlation.GetShard(), true
+	case CorrelationKeyEpoch:
+		return strconv.FormatUint(uint64(correlation.GetEpoch()), 10), true
+	case CorrelationKeyRound:
+		return strconv.FormatInt(correlation.GetRound(), 10), true
+	case CorrelationKeySubRound:
+		return correlation.GetSubRound(), true
+	}
+
+	for _, element := range correlation.GetElements() {
+		if element.GetKey() == key {
+			return element.GetValue(), true
+		}
+	}
+
+	return "", false
+}
+
+func (condition *correlationCondition) matches(value string) bool {
+	if !condition.isNumber {
+		isEqual := value == condition.value
+		return isEqual == (condition.operator == operatorEqual)
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return condition.operator == operatorNotEqual
+	}
+
+	switch condition.operator {
+	case operatorEqual:
+		return number == condition.number
+	case operatorNotEqual:
+		return number != condition.number
+	case operatorGreater:
+		return number > condition.number
+	case operatorGreaterOrEqual:
+		return number >= condition.number
+	case operatorLower:
+		return number < condition.number
+	case operatorLowerOrEqual:
+		return number <= condition.number
+	case operatorIn:
+		return number >= condition.number && number <= condition.upperBound
+	default:
+		return false
+	}
+}
+
+// String returns the filter's expression
+func (cf *correlationFilter) String() string {
+	return cf.expression
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cf *correlationFilter) IsInterfaceNil() bool {
+	return cf == nil
+}
+
+// SetCorrelationFilter sets the correlation filter applied on all the log lines, before being output (see
+// NewCorrelationFilter). The lines outputted through Logger.Log (e.g. received from a child process) are also
+// filtered. An empty expression removes the filter.
+func SetCorrelationFilter(expression string) error {
+	err := setCorrelationFilter(expression)
+	if err != nil {
+		return err
+	}
+
+	onProfileMutated(ProfileChangeSourceSetCorrelationFilter)
+	return nil
+}
+
+func setCorrelationFilter(expression string) error {
+	var filter *correlationFilter
+	if len(strings.TrimSpace(expression)) > 0 {
+		var err error
+		filter, err = NewCorrelationFilter(expression)
+		if err != nil {
+			return err
+		}
+	}
+
+	mutCorrelationFilter.Lock()
+	globalCorrelationFilter = filter
+	mutCorrelationFilter.Unlock()
+
+	return nil
+}
+
+// GetCorrelationFilter returns the expression of the correlation filter applied on all the log lines
+func GetCorrelationFilter() string {
+	mutCorrelationFilter.RLock()
+	defer mutCorrelationFilter.RUnlock()
+
+	if globalCorrelationFilter == nil {
+		return ""
+	}
+
+	return globalCorrelationFilter.expression
+}
+
+func isAllowedByCorrelationFilter(correlation proto.LogCorrelationMessage) bool {
+	mutCorrelationFilter.RLock()
+	filter := globalCorrelationFilter
+	mutCorrelationFilter.RUnlock()
+
+	return filter == nil || filter.Matches(correlation)
+}
+
+// correlationFilterFormatter decorates a formatter so that only the lines matching the correlation filter are
+// formatted. The filtered out lines result in empty buffers.
+type correlationFilterFormatter struct {
+	formatter Formatter
+	filter    *correlationFilter
+}
+
+// NewCorrelationFilterFormatter creates a formatter that only outputs the lines matching the provided
+// correlation filter expression
+func NewCorrelationFilterFormatter(formatter Formatter, expression string) (*correlationFilterFormatter, error) {
+	if check.IfNil(formatter) {
+		return nil, ErrNilFormatter
+	}
+
+	filter, err := NewCorrelationFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &correlationFilterFormatter{
+		formatter: formatter,
+		filter:    filter,
+	}, nil
+}
+
+// Output converts the provided line if it matches the correlation filter
+func (cff *correlationFilterFormatter) Output(line LogLineHandler) []byte {
+	if check.IfNil(line) {
+		return nil
+	}
+	if !cff.filter.Matches(line.GetCorrelation()) {
+		return nil
+	}
+
+	return cff.formatter.Output(line)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cff *correlationFilterFormatter) IsInterfaceNil() bool {
+	return cff == nil
+}
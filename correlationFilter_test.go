@@ -0,0 +1,135 @@
+package logger_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/mock"
+	"github.com/kalyan3104/dme-logger-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCorrelationFilter_InvalidExpressionsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expressions := []string{
+		"",
+		"shard",
+		"=1",
+		"epoch>",
+		"epoch>=twelve",
+		"round in 100,200",
+		"round in [200,100]",
+		"round in [a,b]",
+		"shard=1,,epoch=2",
+	}
+	for _, expression := range expressions {
+		filter, err := logger.NewCorrelationFilter(expression)
+		assert.True(t, filter.IsInterfaceNil(), expression)
+		assert.True(t, errors.Is(err, logger.ErrInvalidCorrelationFilter), expression)
+	}
+}
+
+
+func TestCorrelationFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	filter, err := logger.NewCorrelationFilter("Shard=1, epoch>=12, round in [100, 200], subround=(BLOCK), nonce!=5")
+	require.Nil(t, err)
+	require.Equal(t, "Shard=1, epoch>=12, round in [100, 200], subround=(BLOCK), nonce!=5", filter.String())
+
+	correlation := proto.LogCorrelationMessage{
+		Shard:    "1",
+		Epoch:    12,
+		Round:    100,
+		SubRound: "(BLOCK)",
+		Elements: []proto.LogCorrelationElement{{Key: "nonce", Value: "6"}},
+	}
+	assert.True(t, filter.Matches(correlation))
+
+	mismatches := []func(c *proto.LogCorrelationMessage){
+		func(c *proto.LogCorrelationMessage) { c.Shard = "metachain" },
+		func(c *proto.LogCorrelationMessage) { c.Epoch = 11 },
+		func(c *proto.LogCorrelationMessage) { c.Round = 201 },
+		func(c *proto.LogCorrelationMessage) { c.SubRound = "(END_ROUND)" },
+		func(c *proto.LogCorrelationMessage) { c.Elements[0].Value = "5" },
+		func(c *proto.LogCorrelationMessage) { c.Elements = nil },
+	}
+	for i, mismatch := range mismatches {
+		modified := correlation
+		modified.Elements = append([]proto.LogCorrelationElement(nil), correlation.Elements...)
+		mismatch(&modified)
+		assert.False(t, filter.Matches(modified), i)
+	}
+}
+
+func TestSetCorrelationFilter_ShouldDropTheLinesBeforeOutput(t *testing.T) {
+	defer func() {
+		_ = logger.SetCorrelationFilter("")
+		logger.SetCorrelationRound(0)
+	}()
+
+	numFormatted := int32(0)
+	formatter := &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			atomic.AddInt32(&numFormatted, 1)
+			return nil
+		},
+	}
+	outputSubject := logger.NewLogOutputSubject()
+	_ = outputSubject.AddObserver(&mock.WriterStub{WriteCalled: func(p []byte) (int, error) { return 0, nil }}, formatter)
+	log := logger.NewLogger("filter", logger.LogInfo, outputSubject)
+
+	err := logger.SetCorrelationFilter("round<10")
+	require.Nil(t, err)
+	require.Equal(t, "round<10", logger.GetCorrelationFilter())
+	require.Equal(t, "round<10", logger.GetCurrentProfile().CorrelationFilter)
+
+	logger.SetCorrelationRound(9)
+	log.Info("kept")
+	logger.SetCorrelationRound(10)
+	log.Info("dropped")
+
+	// lines received from a child process are also filtered
+	line := &logger.LogLine{Message: "from child", LogLevel: logger.LogInfo, Timestamp: time.Now()}
+	line.Correlation.Round = 15
+	log.Log(line)
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&numFormatted))
+
+	err = logger.SetCorrelationFilter("round<")
+	require.NotNil(t, err)
+	require.Equal(t, "round<10", logger.GetCorrelationFilter())
+
+	require.Nil(t, logger.SetCorrelationFilter(""))
+	log.Info("kept")
+	require.Equal(t, int32(2), atomic.LoadInt32(&numFormatted))
+}
+
+func TestCorrelationFilterFormatter_Output(t *testing.T) {
+	t.Parallel()
+
+	_, err := logger.NewCorrelationFilterFormatter(nil, "shard=0")
+	assert.Equal(t, logger.ErrNilFormatter, err)
+	_, err = logger.NewCorrelationFilterFormatter(&mock.FormatterStub{}, "shard")
+	assert.True(t, errors.Is(err, logger.ErrInvalidCorrelationFilter))
+
+	formatter := &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			return []byte(line.GetMessage())
+		},
+	}
+	cff, err := logger.NewCorrelationFilterFormatter(formatter, "shard=0")
+	require.Nil(t, err)
+	assert.False(t, cff.IsInterfaceNil())
+
+	line := createLogLineWrapper("message", logger.LogInfo)
+	assert.Nil(t, cff.Output(nil))
+	assert.Nil(t, cff.Output(line))
+	line.Correlation.Shard = "0"
+	assert.Equal(t, []byte("message"), cff.Output(line))
+}
@@ -3,6 +3,7 @@ package logger
 import (
 	"testing"
 
+	"github.com/kalyan3104/dme-logger-go/proto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,3 +34,38 @@ func TestCorrelation_SettingElements(t *testing.T) {
 	require.Equal(t, round, lcm.Round)
 	require.Equal(t, subRound, lcm.SubRound)
 }
+
+func TestCorrelation_SettingNamedElements(t *testing.T) {
+	defer func() {
+		RemoveCorrelation("nonce")
+		RemoveCorrelation("peer")
+		RemoveCorrelation("request")
+	}()
+
+	SetCorrelation("nonce", 122)
+	SetCorrelation("peer", []byte{0xaa, 0xbb})
+	SetCorrelation("request", "req-1")
+	SetCorrelation("nonce", uint64(123))
+	RemoveCorrelation("peer")
+	RemoveCorrelation("missing")
+
+	lcm := GetCorrelation()
+	require.Equal(t, []proto.LogCorrelationElement{
+		{Key: "nonce", Value: "123"},
+		{Key: "request", Value: "req-1"},
+	}, lcm.Elements)
+
+	// the returned elements are a copy
+	lcm.Elements[0].Value = "0"
+	require.Equal(t, "123", GetCorrelation().Elements[0].Value)
+}
+
+func TestFormatCorrelationElements_ShouldRenderNamedElements(t *testing.T) {
+	t.Parallel()
+
+	correlation := proto.LogCorrelationMessage{Shard: "0", Epoch: 1, Round: 2, SubRound: "(BLOCK)"}
+	require.Equal(t, "[0/1/2/(BLOCK)]", formatCorrelationElements(correlation))
+
+	correlation.Elements = []proto.LogCorrelationElement{{Key: "nonce", Value: "123"}, {Key: "peer", Value: "abc"}}
+	require.Equal(t, "[0/1/2/(BLOCK)/nonce=123/peer=abc]", formatCorrelationElements(correlation))
+}
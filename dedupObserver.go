@@ -0,0 +1,264 @@
+package logger
+
+import (
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kalyan3104/dme-logger-go/check"
+)
+
+const repeatedLineMessage = "last message repeated"
+
+var _ Formatter = (*dedupObserver)(nil)
+var _ Flusher = (*dedupObserver)(nil)
+
+// repeatedLines is a run of identical lines of a logger: the first one has been output, the following ones
+// (count of them, from first to last timestamp) have been collapsed
+type repeatedLines struct {
+	loggerName string
+	logLevel   int32
+	message    string
+	args       []string
+	count      int
+	first      int64
+	last       int64
+	expiry     time.Time
+}
+
+func (run *repeatedLines) matches(line LogLineHandler) bool {
+	if run.logLevel != line.GetLogLevel() || run.message != line.GetMessage() {
+		return false
+	}
+
+	args := line.GetArgs()
+	if len(run.args) != len(args) {
+		return false
+	}
+	for i := range args {
+		if run.args[i] != args[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dedupObserver collapses the consecutive identical lines (same logger, level, message and arguments) of each logger
+// output within a window, emitting a summary line with the number of collapsed lines and their first and last
+// timestamps. The runs are tracked per logger, so the lines of the concurrent loggers do not interrupt each other's
+// runs. The expired runs are swept by a single ticker, running while there are runs. It is both the formatter and
+// the writer of an observer:
+//
+//	dedup, _ := logger.NewDedupObserver(file, &logger.PlainFormatter{}, 10 * time.Second)
+//	_ = logger.AddLogObserver(dedup, dedup)
+type dedupObserver struct {
+	writer    io.Writer
+	formatter Formatter
+	window    time.Duration
+	mut       sync.Mutex
+	mutWrite  sync.Mutex
+	runs      map[string]*repeatedLines
+	chStop    chan struct{}
+	closed    bool
+}
+
+// NewDedupObserver creates an observer that formats the log lines with the provided formatter and writes them on the
+// provided writer, collapsing the identical lines of a logger repeated within the provided window
+func NewDedupObserver(writer io.Writer, formatter Formatter, window time.Duration) (*dedupObserver, error) {
+	if writer == nil {
+		return nil, ErrNilWriter
+	}
+	if check.IfNil(formatter) {
+		return nil, ErrNilFormatter
+	}
+	if window <= 0 {
+		return nil, ErrInvalidDedupWindow
+	}
+
+	return &dedupObserver{
+		writer:    writer,
+		formatter: formatter,
+		window:    window,
+		runs:      make(map[string]*repeatedLines),
+	}, nil
+}
+
+// Output formats the provided line, unless it repeats the previous line of its logger. A line ending a run
+// is preceded by the run's summary.
+func (do *dedupObserver) Output(line LogLineHandler) []byte {
+	if check.IfNil(line) {
+		return nil
+	}
+
+	// the lines dropped by the wrapped formatter (filters) do not take part in the runs
+	buff := do.formatter.Output(line)
+	if len(buff) == 0 {
+		return nil
+	}
+
+	do.mut.Lock()
+	defer do.mut.Unlock()
+
+	loggerName := line.GetLoggerName()
+	run, ok := do.runs[loggerName]
+	if ok && run.matches(line) {
+		if run.count == 0 {
+			run.first = line.GetTimestamp()
+		}
+		run.count++
+		run.last = line.GetTimestamp()
+
+		return nil
+	}
+
+	var summary []byte
+	if ok {
+		summary = do.summary(run)
+	}
+	if do.closed {
+		delete(do.runs, loggerName)
+		return append(summary, buff...)
+	}
+
+	do.runs[loggerName] = &repeatedLines{
+		loggerName: loggerName,
+		logLevel:   line.GetLogLevel(),
+		message:    line.GetMessage(),
+		args:       line.GetArgs(),
+		expiry:     time.Now().Add(do.window),
+	}
+	if do.chStop == nil {
+		do.chStop = make(chan struct{})
+		go do.continuouslySweep(do.chStop)
+	}
+
+	return append(summary, buff...)
+}
+
+// continuouslySweep ends the expired runs twice per window, so that a run lasts at most one and a half windows.
+// It returns once there are no more runs, the next run starting it again.
+func (do *dedupObserver) continuouslySweep(chStop chan struct{}) {
+	interval := do.window / 2
+	if interval <= 0 {
+		interval = do.window
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !do.sweep(chStop) {
+				return
+			}
+		case <-chStop:
+			return
+		}
+	}
+}
+
+// sweep ends the expired runs, writing their summaries, and returns false once there are no more runs
+func (do *dedupObserver) sweep(chStop chan struct{}) bool {
+	do.mut.Lock()
+	defer do.mut.Unlock()
+
+	now := time.Now()
+	for loggerName, run := range do.runs {
+		if now.Before(run.expiry) {
+			continue
+		}
+
+		delete(do.runs, loggerName)
+		do.writeSummary(run)
+	}
+
+	if len(do.runs) > 0 {
+		return true
+	}
+	if do.chStop == chStop {
+		do.chStop = nil
+	}
+
+	return false
+}
+
+// summary formats the summary line of the run, if it collapsed any line, and restarts its count.
+// Must be called under mut.
+func (do *dedupObserver) summary(run *repeatedLines) []byte {
+	if run.count == 0 {
+		return nil
+	}
+
+	line := &LogLineWrapper{}
+	line.LoggerName = run.loggerName
+	line.LogLevel = run.logLevel
+	line.Message = repeatedLineMessage
+	line.Timestamp = run.last
+	line.Args = []string{
+		"message", run.message,
+		"count", strconv.Itoa(run.count),
+		"first", displayTime(run.first),
+		"last", displayTime(run.last),
+	}
+	run.count = 0
+
+	return do.formatter.Output(line)
+}
+
+// writeSummary must be called under mut
+func (do *dedupObserver) writeSummary(run *repeatedLines) {
+	summary := do.summary(run)
+	if len(summary) == 0 {
+		return
+	}
+
+	_, _ = do.Write(summary)
+}
+
+// Write writes the formatted lines on the wrapped writer
+func (do *dedupObserver) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	do.mutWrite.Lock()
+	defer do.mutWrite.Unlock()
+
+	return do.writer.Write(p)
+}
+
+// Flush writes the summaries of the pending runs and then flushes the wrapped writer. The runs go on.
+func (do *dedupObserver) Flush() error {
+	do.mut.Lock()
+	for _, run := range do.runs {
+		do.writeSummary(run)
+	}
+	do.mut.Unlock()
+
+	return flushWriter(do.writer)
+}
+
+// Close writes the summaries of the pending runs and stops collapsing the lines. The wrapped writer is not closed.
+func (do *dedupObserver) Close() error {
+	do.mut.Lock()
+	defer do.mut.Unlock()
+
+	for loggerName, run := range do.runs {
+		do.writeSummary(run)
+		delete(do.runs, loggerName)
+	}
+	if do.chStop != nil {
+		close(do.chStop)
+		do.chStop = nil
+	}
+	do.closed = true
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (do *dedupObserver) IsInterfaceNil() bool {
+	return do == nil
+}
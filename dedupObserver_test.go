@@ -0,0 +1,202 @@
+package logger_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kalyan3104/dme-logger-go"
+	"github.com/stretchr/testify/require"
+)
+
+type safeBuffer struct {
+	mut  sync.Mutex
+	buff bytes.Buffer
+}
+
+func (sb *safeBuffer) Write(p []byte) (int, error) {
+	sb.mut.Lock()
+	defer sb.mut.Unlock()
+
+	return sb.buff.Write(p)
+}
+
+func (sb *safeBuffer) String() string {
+	sb.mut.Lock()
+	defer sb.mut.Unlock()
+
+	return sb.buff.String()
+}
+
+func createDedupLine(loggerName string, level logger.LogLevel, message string, args ...string) *logger.LogLineWrapper {
+	line := &logger.LogLineWrapper{}
+	line.LoggerName = loggerName
+	line.LogLevel = int32(level)
+	line.Message = message
+	line.Args = args
+	line.Timestamp = time.Now().UnixNano()
+
+	return line
+}
+
+func outputDedupLine(do logger.Formatter, writer *safeBuffer, line logger.LogLineHandler) {
+	_, _ = writer.Write(do.Output(line))
+}
+
+func TestNewDedupObserver_InvalidArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	do, err := logger.NewDedupObserver(nil, &logger.PlainFormatter{}, time.Second)
+	require.Nil(t, do)
+	require.Equal(t, logger.ErrNilWriter, err)
+
+	do, err = logger.NewDedupObserver(&bytes.Buffer{}, nil, time.Second)
+	require.Nil(t, do)
+	require.Equal(t, logger.ErrNilFormatter, err)
+
+	do, err = logger.NewDedupObserver(&bytes.Buffer{}, &logger.PlainFormatter{}, 0)
+	require.Nil(t, do)
+	require.Equal(t, logger.ErrInvalidDedupWindow, err)
+
+	do, err = logger.NewDedupObserver(&bytes.Buffer{}, &logger.PlainFormatter{}, time.Second)
+	require.Nil(t, err)
+	require.False(t, do.IsInterfaceNil())
+}
+
+func TestDedupObserver_ShouldCollapseTheRepeatedLines(t *testing.T) {
+	t.Parallel()
+
+	buff := &safeBuffer{}
+	do, _ := logger.NewDedupObserver(buff, &logger.PlainFormatter{}, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		outputDedupLine(do, buff, createDedupLine("main", logger.LogWarning, "peer unreachable", "peer", "a"))
+	}
+	require.Equal(t, 1, strings.Count(buff.String(), "peer unreachable"))
+
+	outputDedupLine(do, buff, createDedupLine("main", logger.LogWarning, "peer unreachable", "peer", "b"))
+	output := buff.String()
+	require.Equal(t, 1, strings.Count(output, "last message repeated"))
+	require.Contains(t, output, "count = 4")
+	require.True(t, strings.Index(output, "last message repeated") < strings.Index(output, "peer = b"))
+
+	_ = do.Close()
+}
+
+func TestDedupObserver_ConcurrentLoggersShouldNotInterruptTheRuns(t *testing.T) {
+	t.Parallel()
+
+	buff := &safeBuffer{}
+	do, _ := logger.NewDedupObserver(buff, &logger.PlainFormatter{}, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		outputDedupLine(do, buff, createDedupLine("a", logger.LogInfo, "tick"))
+		outputDedupLine(do, buff, createDedupLine("b", logger.LogInfo, "tock"))
+	}
+	output := buff.String()
+	require.Equal(t, 1, strings.Count(output, "tick"))
+	require.Equal(t, 1, strings.Count(output, "tock"))
+	require.NotContains(t, output, "last message repeated")
+
+	require.Nil(t, do.Close())
+	output = buff.String()
+	require.Equal(t, 2, strings.Count(output, "last message repeated"))
+	require.Equal(t, 2, strings.Count(output, "count = 2"))
+}
+
+func TestDedupObserver_WindowExpiryShouldWriteTheSummary(t *testing.T) {
+	t.Parallel()
+
+	buff := &safeBuffer{}
+	do, _ := logger.NewDedupObserver(buff, &logger.PlainFormatter{}, 50*time.Millisecond)
+
+	outputDedupLine(do, buff, createDedupLine("main", logger.LogInfo, "sync"))
+	outputDedupLine(do, buff, createDedupLine("main", logger.LogInfo, "sync"))
+	require.Eventually(t, func() bool {
+		return strings.Contains(buff.String(), "count = 1")
+	}, time.Second, 10*time.Millisecond)
+
+	outputDedupLine(do, buff, createDedupLine("main", logger.LogInfo, "sync"))
+	// the expired run does not collapse the new line: first line, summary, new line
+	require.Equal(t, 3, strings.Count(buff.String(), "\n"))
+}
+
+func TestDedupObserver_SweepingShouldResumeWithTheNextRuns(t *testing.T) {
+	t.Parallel()
+
+	buff := &safeBuffer{}
+	do, _ := logger.NewDedupObserver(buff, &logger.PlainFormatter{}, 50*time.Millisecond)
+	defer func() {
+		_ = do.Close()
+	}()
+
+	for i := 0; i < 2; i++ {
+		outputDedupLine(do, buff, createDedupLine("a", logger.LogInfo, "sync"))
+		outputDedupLine(do, buff, createDedupLine("b", logger.LogInfo, "sync"))
+		outputDedupLine(do, buff, createDedupLine("a", logger.LogInfo, "sync"))
+		outputDedupLine(do, buff, createDedupLine("b", logger.LogInfo, "sync"))
+
+		// the sweeping stops once both runs end and resumes with the next runs
+		numSummaries := 2 * (i + 1)
+		require.Eventually(t, func() bool {
+			return strings.Count(buff.String(), "last message repeated") == numSummaries
+		}, time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestDedupObserver_FilteredLinesShouldNotTakePartInTheRuns(t *testing.T) {
+	t.Parallel()
+
+	buff := &safeBuffer{}
+	filter, _ := logger.NewLevelFilterFormatter(&logger.PlainFormatter{}, logger.LogInfo)
+	do, _ := logger.NewDedupObserver(buff, filter, time.Minute)
+
+	outputDedupLine(do, buff, createDedupLine("main", logger.LogInfo, "started"))
+	outputDedupLine(do, buff, createDedupLine("main", logger.LogDebug, "noise"))
+	outputDedupLine(do, buff, createDedupLine("main", logger.LogInfo, "started"))
+	require.Nil(t, do.Flush())
+
+	output := buff.String()
+	require.NotContains(t, output, "noise")
+	require.Contains(t, output, "count = 1")
+	_ = do.Close()
+}
+
+func TestDedupObserver_ClosedShouldStopCollapsing(t *testing.T) {
+	t.Parallel()
+
+	buff := &safeBuffer{}
+	do, _ := logger.NewDedupObserver(buff, &logger.PlainFormatter{}, time.Minute)
+	require.Nil(t, do.Close())
+
+	outputDedupLine(do, buff, createDedupLine("main", logger.LogInfo, "closed"))
+	outputDedupLine(do, buff, createDedupLine("main", logger.LogInfo, "closed"))
+	require.Equal(t, 2, strings.Count(buff.String(), "closed"))
+}
+
+func TestDedupObserver_ConcurrentOutputShouldCountEveryLine(t *testing.T) {
+	t.Parallel()
+
+	buff := &safeBuffer{}
+	do, _ := logger.NewDedupObserver(buff, &logger.PlainFormatter{}, time.Minute)
+
+	numGoroutines := 10
+	numLines := 100
+	wg := sync.WaitGroup{}
+	wg.Add(numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numLines; j++ {
+				_, _ = do.Write(do.Output(createDedupLine("main", logger.LogInfo, "busy")))
+			}
+		}()
+	}
+	wg.Wait()
+	require.Nil(t, do.Close())
+
+	output := buff.String()
+	require.Contains(t, output, "count = 999")
+}
@@ -0,0 +1,158 @@
+package logger
+
+import "context"
+
+var _ Logger = (*derivedLogger)(nil)
+
+// derivedLogger is a logger that stamps its own options (trace and span IDs, caller skip depth) on each of its
+// log lines. It shares the log level and the caller capture setting of the logger it has been derived from.
+type derivedLogger struct {
+	*logger
+	options lineOptions
+}
+
+// Trace outputs a tracing log message with optional provided arguments
+func (dl *derivedLogger) Trace(message string, args ...interface{}) {
+	dl.outputMessage(LogTrace, dl.options, message, args...)
+}
+
+// Debug outputs a debugging log message with optional provided arguments
+func (dl *derivedLogger) Debug(message string, args ...interface{}) {
+	dl.outputMessage(LogDebug, dl.options, message, args...)
+}
+
+// Info outputs an information log message with optional provided arguments
+func (dl *derivedLogger) Info(message string, args ...interface{}) {
+	dl.outputMessage(LogInfo, dl.options, message, args...)
+}
+
+// Warn outputs a warning log message with optional provided arguments
+func (dl *derivedLogger) Warn(message string, args ...interface{}) {
+	dl.outputMessage(LogWarning, dl.options, message, args...)
+}
+
+// Error outputs an error log message with optional provided arguments
+func (dl *derivedLogger) Error(message string, args ...interface{}) {
+	dl.outputMessage(LogError, dl.options, message, args...)
+}
+
+// Panic outputs a panic log message with optional provided arguments, flushes the observers and then panics
+// with the message
+func (dl *derivedLogger) Panic(message string, args ...interface{}) {
+	dl.outputMessage(LogPanic, dl.options, message, args...)
+	dl.flushOutput()
+	panic(message)
+}
+
+// Fatal outputs a fatal log message with optional provided arguments, flushes the observers, runs the registered
+// exit hooks and then exits the process with code 1
+func (dl *derivedLogger) Fatal(message string, args ...interface{}) {
+	dl.outputMessage(LogFatal, dl.options, message, args...)
+	dl.flushOutput()
+	exit(fatalExitCode)
+}
+
+// LogAt outputs a log message on the provided (built-in or custom) log level with optional provided arguments
+func (dl *derivedLogger) LogAt(level LogLevel, message string, args ...interface{}) {
+	dl.outputMessage(level, dl.options, message, args...)
+}
+
+// LogIfError outputs an error log message with optional provided arguments if the provided error parameter is not nil.
+// The errors wrapped by the provided one are appended to the arguments, along with the fields of the errors
+// implementing ErrorWithFields, while the stack of the first ErrorWithStack error is output as the line's stack.
+func (dl *derivedLogger) LogIfError(err error, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	if dl.shouldOutput(LogError) {
+		return
+	}
+
+	options, args := withErrorDetails(dl.options, err, args)
+	dl.outputMessage(LogError, options, err.Error(), args...)
+}
+
+// Log forwards the log line towards underlying log output handler, stamping the trace and span IDs if the line
+// does not already carry them
+func (dl *derivedLogger) Log(line *LogLine) {
+	if line == nil {
+		return
+	}
+
+	if len(line.TraceID) == 0 && len(line.SpanID) == 0 {
+		tracedLine := *line
+		tracedLine.TraceID = dl.options.traceID
+		tracedLine.SpanID = dl.options.spanID
+		line = &tracedLine
+	}
+
+	dl.logger.Log(line)
+}
+
+// WithTrace returns a logger stamping the provided trace and span IDs on its log lines
+func (dl *derivedLogger) WithTrace(traceID string, spanID string) Logger {
+	options := dl.options
+	options.traceID = traceID
+	options.spanID = spanID
+
+	return dl.derive(options)
+}
+
+// WithContext returns a logger stamping the trace and span IDs carried by the provided context on its log lines.
+// The logger itself is returned if the context does not carry them.
+func (dl *derivedLogger) WithContext(ctx context.Context) Logger {
+	traceID, spanID, ok := TraceFromContext(ctx)
+	if !ok {
+		return dl
+	}
+
+	return dl.WithTrace(traceID, spanID)
+}
+
+// WithCallerSkip returns a logger skipping the provided number of additional stack frames when capturing the caller
+func (dl *derivedLogger) WithCallerSkip(skip int) Logger {
+	options := dl.options
+	options.callerSkip += skip
+
+	return dl.derive(options)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dl *derivedLogger) IsInterfaceNil() bool {
+	return dl == nil
+}
+
+// WithTrace returns a logger stamping the provided trace and span IDs on its log lines
+func (l *logger) WithTrace(traceID string, spanID string) Logger {
+	return l.derive(lineOptions{
+		traceID: traceID,
+		spanID:  spanID,
+	})
+}
+
+// WithContext returns a logger stamping the trace and span IDs carried by the provided context (see ContextWithTrace)
+// on its log lines. The logger itself is returned if the context does not carry them.
+func (l *logger) WithContext(ctx context.Context) Logger {
+	traceID, spanID, ok := TraceFromContext(ctx)
+	if !ok {
+		return l
+	}
+
+	return l.WithTrace(traceID, spanID)
+}
+
+// WithCallerSkip returns a logger skipping the provided number of additional stack frames when capturing the caller,
+// to be used by the logging wrappers so that the captured caller is the wrapper's caller
+func (l *logger) WithCallerSkip(skip int) Logger {
+	return l.derive(lineOptions{
+		callerSkip: skip,
+	})
+}
+
+func (l *logger) derive(options lineOptions) *derivedLogger {
+	return &derivedLogger{
+		logger:  l,
+		options: options,
+	}
+}
@@ -1,10 +1,16 @@
 package logger
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrNilWriter signals that a nil writer has been provided
 var ErrNilWriter = errors.New("nil writer provided")
 
+// ErrNilLogger signals that a nil logger has been provided
+var ErrNilLogger = errors.New("nil logger provided")
+
 // ErrNilFormatter signals that a nil formatter has been provided
 var ErrNilFormatter = errors.New("nil formatter provided")
 
@@ -19,3 +25,118 @@ var ErrNilMarshalizer = errors.New("nil marshalizer")
 
 // ErrNilDisplayByteSliceHandler signals that a nil display byte slice handler has been provided
 var ErrNilDisplayByteSliceHandler = errors.New("nil display byte slice handler")
+
+// ErrObserversLengthMismatch signals that the provided writers and formatters lists have different lengths
+var ErrObserversLengthMismatch = errors.New("writers and formatters lists have different lengths")
+
+// ErrEmptyTimestampFormat signals that an empty timestamp format has been provided
+var ErrEmptyTimestampFormat = errors.New("empty timestamp format")
+
+// ErrInvalidQueueSize signals that an invalid queue size has been provided
+var ErrInvalidQueueSize = errors.New("invalid queue size")
+
+// ErrInvalidProfile signals that the provided profile did not pass the validation
+var ErrInvalidProfile = errors.New("invalid profile")
+
+// ErrInvalidCorrelationFilter signals that an invalid correlation filter expression has been provided
+var ErrInvalidCorrelationFilter = errors.New("invalid correlation filter")
+
+// ErrInvalidCallerPatterns signals that invalid caller patterns have been provided
+var ErrInvalidCallerPatterns = errors.New("invalid caller patterns")
+
+// ErrInvalidStackTracePatterns signals that invalid stack trace patterns have been provided
+var ErrInvalidStackTracePatterns = errors.New("invalid stack trace patterns")
+
+// ErrFlushTimeout signals that the pending log lines could not be written in time while flushing
+var ErrFlushTimeout = errors.New("flush timeout")
+
+// ErrNilExitFunction signals that a nil exit function has been provided
+var ErrNilExitFunction = errors.New("nil exit function")
+
+// ErrNilExitHook signals that a nil exit hook has been provided
+var ErrNilExitHook = errors.New("nil exit hook")
+
+// ErrInvalidLogLevelName signals that an invalid custom log level name has been provided
+var ErrInvalidLogLevelName = errors.New("invalid log level name")
+
+// ErrInvalidLogLevelColor signals that an invalid custom log level color has been provided
+var ErrInvalidLogLevelColor = errors.New("invalid log level color, expected an ANSI SGR sequence such as 0;35m")
+
+// ErrInvalidLogLevelPriority signals that an invalid custom log level priority has been provided
+var ErrInvalidLogLevelPriority = errors.New("invalid log level priority")
+
+// ErrLogLevelAlreadyRegistered signals that the custom log level's value, name or priority is already registered
+var ErrLogLevelAlreadyRegistered = errors.New("log level already registered")
+
+// ErrInvalidLineSuppression signals that invalid rate limits or sampling rules have been provided
+var ErrInvalidLineSuppression = errors.New("invalid line suppression")
+
+// ErrInvalidRedactionRule signals that an invalid redaction rule has been provided
+var ErrInvalidRedactionRule = errors.New("invalid redaction rule")
+
+// ErrNilArgRenderer signals that a nil argument renderer has been provided
+var ErrNilArgRenderer = errors.New("nil argument renderer")
+
+// ErrNilRenderFunc signals that a nil render function has been provided
+var ErrNilRenderFunc = errors.New("nil render function")
+
+// ErrNilArgType signals that a nil sample value or interface pointer has been provided instead of an argument type
+var ErrNilArgType = errors.New("nil argument type")
+
+// ErrInvalidArgInterface signals that the provided value is not a pointer to an interface, such as (*fmt.Stringer)(nil)
+var ErrInvalidArgInterface = errors.New("invalid argument interface, expected a nil pointer to an interface")
+
+// ErrInvalidKeyPattern signals that an invalid argument key pattern has been provided
+var ErrInvalidKeyPattern = errors.New("invalid argument key pattern")
+
+// ErrInvalidBech32Prefix signals that an invalid bech32 human readable prefix has been provided
+var ErrInvalidBech32Prefix = errors.New("invalid bech32 prefix")
+
+// ErrInvalidDedupWindow signals that an invalid duplicate lines window has been provided
+var ErrInvalidDedupWindow = errors.New("invalid dedup window")
+
+// ErrInvalidTraceparent signals that an invalid W3C traceparent header has been provided
+var ErrInvalidTraceparent = errors.New("invalid traceparent")
+
+// ErrInvalidLogLevelOverrideDuration signals that a non-positive log level override duration has been provided
+var ErrInvalidLogLevelOverrideDuration = errors.New("invalid log level override duration")
+
+func createErrInvalidCorrelationFilter(condition string, reason string) error {
+	return fmt.Errorf("%w: '%s': %s", ErrInvalidCorrelationFilter, condition, reason)
+}
+
+func createErrInvalidTraceparent(header string, reason string) error {
+	return fmt.Errorf("%w '%s': %s", ErrInvalidTraceparent, header, reason)
+}
+
+func createErrInvalidCallerPatterns(patterns string, reason string) error {
+	return fmt.Errorf("%w '%s': %s", ErrInvalidCallerPatterns, patterns, reason)
+}
+
+func createErrInvalidStackTracePatterns(patterns string, reason string) error {
+	return fmt.Errorf("%w '%s': %s", ErrInvalidStackTracePatterns, patterns, reason)
+}
+
+func createErrInvalidLogLevelName(name string) error {
+	return fmt.Errorf("%w '%s'", ErrInvalidLogLevelName, name)
+}
+
+func createErrInvalidLogLevelColor(color string) error {
+	return fmt.Errorf("%w: '%s'", ErrInvalidLogLevelColor, color)
+}
+
+func createErrInvalidLogLevelPriority(priority int) error {
+	return fmt.Errorf("%w: %d, expected a value between 0 and %d", ErrInvalidLogLevelPriority, priority, unregisteredLevelPriority-1)
+}
+
+func createErrLogLevelAlreadyRegistered(name string, level LogLevel) error {
+	return fmt.Errorf("%w: '%s' (%d)", ErrLogLevelAlreadyRegistered, name, level)
+}
+
+func createErrInvalidLineSuppression(element string, reason string) error {
+	return fmt.Errorf("%w: %s: %s", ErrInvalidLineSuppression, element, reason)
+}
+
+func createErrInvalidRedactionRule(element string, reason string) error {
+	return fmt.Errorf("%w: %s: %s", ErrInvalidRedactionRule, element, reason)
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+const fatalExitCode = 1
+
+var mutExit = &sync.Mutex{}
+var exitFunction = os.Exit
+var exitHooks []func()
+
+// RegisterExitHook registers a function called by TerminatingLogger.Fatal before exiting the process. The hooks are called
+// in the order they have been registered, a panicking hook not preventing the others from being called.
+func RegisterExitHook(hook func()) error {
+	if hook == nil {
+		return ErrNilExitHook
+	}
+
+	mutExit.Lock()
+	exitHooks = append(exitHooks, hook)
+	mutExit.Unlock()
+
+	return nil
+}
+
+// SetExitFunction sets the function called by TerminatingLogger.Fatal to exit the process, os.Exit by default
+func SetExitFunction(f func(code int)) error {
+	if f == nil {
+		return ErrNilExitFunction
+	}
+
+	mutExit.Lock()
+	exitFunction = f
+	mutExit.Unlock()
+
+	return nil
+}
+
+// exit runs the exit hooks and then calls the exit function. The hooks are run only once.
+func exit(code int) {
+	mutExit.Lock()
+	hooks := exitHooks
+	exitHooks = nil
+	f := exitFunction
+	mutExit.Unlock()
+
+	for _, hook := range hooks {
+		runExitHook(hook)
+	}
+
+	f(code)
+}
+
+func runExitHook(hook func()) {
+	defer func() {
+		_ = recover()
+	}()
+
+	hook()
+}
+
+// flushWriter flushes the writers implementing Flusher or having a Sync method, such as os.File
+func flushWriter(w io.Writer) error {
+	switch writer := w.(type) {
+	case Flusher:
+		return writer.Flush()
+	case interface{ Sync() error }:
+		return writer.Sync()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type flushableWriter struct {
+	bytes.Buffer
+	numFlushes int32
+}
+
+func (fw *flushableWriter) Flush() error {
+	atomic.AddInt32(&fw.numFlushes, 1)
+	return nil
+}
+
+func TestSetExitFunctionAndRegisterExitHook_NilShouldErr(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ErrNilExitFunction, SetExitFunction(nil))
+	require.Equal(t, ErrNilExitHook, RegisterExitHook(nil))
+}
+
+func TestLogger_FatalShouldFlushRunTheHooksAndExit(t *testing.T) {
+	exitCode := -1
+	_ = SetExitFunction(func(code int) {
+		exitCode = code
+	})
+	defer func() {
+		_ = SetExitFunction(os.Exit)
+	}()
+
+	calls := make([]string, 0)
+	_ = RegisterExitHook(func() {
+		calls = append(calls, "first")
+		panic("hook panic")
+	})
+	_ = RegisterExitHook(func() {
+		calls = append(calls, "second")
+	})
+
+	writer := &flushableWriter{}
+	los := NewLogOutputSubject()
+	_ = los.AddObserver(writer, &PlainFormatter{})
+	log := NewLogger("test", LogInfo, los)
+
+	log.Fatal("fatal message", "code", 1)
+	require.Equal(t, 1, exitCode)
+	require.Equal(t, []string{"first", "second"}, calls)
+	require.Equal(t, int32(1), atomic.LoadInt32(&writer.numFlushes))
+	require.True(t, strings.HasPrefix(writer.String(), "FATAL"))
+	require.True(t, strings.Contains(writer.String(), "fatal message"))
+
+	// the hooks are run only once, the derived loggers exit as well
+	exitCode = -1
+	log.WithTrace("trace", "span").(TerminatingLogger).Fatal("again")
+	require.Equal(t, 1, exitCode)
+	require.Equal(t, 2, len(calls))
+	require.Equal(t, int32(2), atomic.LoadInt32(&writer.numFlushes))
+}
+
+func TestLogger_PanicShouldFlushAndPanic(t *testing.T) {
+	t.Parallel()
+
+	writer := &flushableWriter{}
+	los := NewLogOutputSubject()
+	_ = los.AddObserver(writer, &PlainFormatter{})
+	log := NewLogger("test", LogNone, los)
+
+	require.PanicsWithValue(t, "panic message", func() {
+		log.Panic("panic message")
+	})
+	require.Equal(t, int32(1), atomic.LoadInt32(&writer.numFlushes))
+	require.Equal(t, "", writer.String())
+
+	log.SetLevel(LogPanic)
+	require.PanicsWithValue(t, "panic message", func() {
+		log.WithCallerSkip(0).(TerminatingLogger).Panic("panic message")
+	})
+	require.True(t, strings.HasPrefix(writer.String(), "PANIC"))
+}
+
+func TestLogLevels_PanicAndFatal(t *testing.T) {
+	t.Parallel()
+
+	level, err := GetLogLevel("fatal")
+	require.Nil(t, err)
+	require.Equal(t, LogFatal, level)
+
+	level, err = GetLogLevel("PANIC")
+	require.Nil(t, err)
+	require.Equal(t, LogPanic, level)
+
+	require.True(t, LogPanic.IsAtLeast(LogError) && LogFatal.IsAtLeast(LogPanic) && !LogFatal.IsAtLeast(LogNone))
+	require.Equal(t, ansiRegularMagenta, getLevelColor(LogPanic))
+	require.Equal(t, ansiBoldRed, getLevelColor(LogFatal))
+}
+
+func TestAsyncWriter_FlushShouldWriteThePendingBuffers(t *testing.T) {
+	t.Parallel()
+
+	writer := &flushableWriter{}
+	aw, _ := NewAsyncWriter(writer, 100)
+	defer func() {
+		_ = aw.Close()
+	}()
+
+	for i := 0; i < 10; i++ {
+		_, _ = aw.Write([]byte("line\n"))
+	}
+
+	err := aw.Flush()
+	require.Nil(t, err)
+	require.Equal(t, strings.Repeat("line\n", 10), writer.String())
+	require.Equal(t, int32(1), atomic.LoadInt32(&writer.numFlushes))
+}
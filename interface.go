@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"io"
 
 	"github.com/kalyan3104/dme-logger-go/proto"
@@ -20,6 +21,36 @@ type Logger interface {
 	IsInterfaceNil() bool
 }
 
+// TraceLogger is implemented by the loggers able to derive loggers stamping trace and span IDs on their log lines,
+// as the loggers of this package do
+type TraceLogger interface {
+	Logger
+	WithTrace(traceID string, spanID string) Logger
+	WithContext(ctx context.Context) Logger
+}
+
+// CallerSkipLogger is implemented by the loggers able to derive loggers skipping additional stack frames when
+// capturing the caller, as the loggers of this package do
+type CallerSkipLogger interface {
+	Logger
+	WithCallerSkip(skip int) Logger
+}
+
+// TerminatingLogger is implemented by the loggers able to output log lines terminating the execution, either by
+// panicking or by exiting the process, as the loggers of this package do
+type TerminatingLogger interface {
+	Logger
+	Panic(message string, args ...interface{})
+	Fatal(message string, args ...interface{})
+}
+
+// LevelLogger is implemented by the loggers able to output log lines on any log level, custom levels included,
+// as the loggers of this package do
+type LevelLogger interface {
+	Logger
+	LogAt(level LogLevel, message string, args ...interface{})
+}
+
 // LogLineHandler defines the get methods for a log line struct used by the formatter interface
 type LogLineHandler interface {
 	GetLoggerName() string
@@ -31,6 +62,17 @@ type LogLineHandler interface {
 	IsInterfaceNil() bool
 }
 
+// LogLineDetailsHandler is implemented by the log lines carrying the trace context, the caller and the stack trace of
+// their logging call, as the lines handed to the formatters by this package do. The formatters output these details
+// only for the lines implementing it.
+type LogLineDetailsHandler interface {
+	LogLineHandler
+	GetTraceID() string
+	GetSpanID() string
+	GetCaller() *proto.LogCallerMessage
+	GetStack() []string
+}
+
 // Formatter describes what a log formatter should be able to do
 type Formatter interface {
 	Output(line LogLineHandler) []byte
@@ -58,3 +100,71 @@ type Marshalizer interface {
 type ProfileChangeObserver interface {
 	OnProfileChanged()
 }
+
+// ProfileChangeEventObserver defines the interface for observing profile changes, along with their details
+type ProfileChangeEventObserver interface {
+	OnProfileChangeEvent(event ProfileChangeEvent)
+}
+
+// ProfileApplyObserver defines the interface for observing the profiles applied by Profile.Apply
+type ProfileApplyObserver interface {
+	OnProfileApplied(profile Profile)
+}
+
+// Flusher is implemented by the writers buffering the log lines. The observers are flushed before the process
+// exits (TerminatingLogger.Fatal) or panics (TerminatingLogger.Panic).
+type Flusher interface {
+	Flush() error
+}
+
+// ErrorWithStack is implemented by the errors carrying the stack trace of the place they have been created at,
+// one frame per element. LogIfError outputs it instead of capturing the current stack.
+type ErrorWithStack interface {
+	error
+	LogStack() []string
+}
+
+// ErrorWithFields is implemented by the errors carrying their own structured fields, in the same
+// "name1", val1, "name2", val2 ... form as the log arguments. LogIfError appends them to the arguments.
+type ErrorWithFields interface {
+	error
+	LogFields() []interface{}
+}
+
+// ArgRenderer converts the log arguments into their displayed strings (see NewArgRendererRegistry)
+type ArgRenderer interface {
+	Render(arg interface{}) string
+	IsInterfaceNil() bool
+}
+
+// KeyedArgRenderer is implemented by the argument renderers depending on the argument keys, such as the registries
+// holding per key byte slice displays. The key is the rendered preceding argument for the values of the key-value
+// argument pairs, empty for the keys.
+type KeyedArgRenderer interface {
+	ArgRenderer
+	RenderKeyed(key string, arg interface{}) string
+}
+
+// ArgRendererProvider is implemented by the formatters rendering the arguments of their lines with their own
+// ArgRenderer instead of the default one (see NewArgRenderingFormatter)
+type ArgRendererProvider interface {
+	ArgRenderer() ArgRenderer
+}
+
+// LogMarshaler is implemented by the types providing their own log argument representation
+type LogMarshaler interface {
+	MarshalLog() string
+}
+
+// Sensitive is a marker interface implemented by the types whose values must never be output in clear (private keys,
+// mnemonics, auth tokens). Such log arguments are redacted regardless of the redaction rules' keys and values.
+type Sensitive interface {
+	LogSensitive()
+}
+
+// CorrelationFilter selects the log lines by their correlation elements
+type CorrelationFilter interface {
+	Matches(correlation proto.LogCorrelationMessage) bool
+	String() string
+	IsInterfaceNil() bool
+}
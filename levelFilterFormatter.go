@@ -0,0 +1,40 @@
+package logger
+
+import "github.com/kalyan3104/dme-logger-go/check"
+
+// levelFilterFormatter decorates a formatter so that only the lines having at least the provided log level
+// are formatted. The filtered out lines result in empty buffers.
+type levelFilterFormatter struct {
+	formatter Formatter
+	logLevel  LogLevel
+}
+
+// NewLevelFilterFormatter creates a formatter that only outputs the lines with a log level greater or equal
+// to the provided one
+func NewLevelFilterFormatter(formatter Formatter, logLevel LogLevel) (*levelFilterFormatter, error) {
+	if check.IfNil(formatter) {
+		return nil, ErrNilFormatter
+	}
+
+	return &levelFilterFormatter{
+		formatter: formatter,
+		logLevel:  logLevel,
+	}, nil
+}
+
+// Output converts the provided line if its log level is high enough
+func (lff *levelFilterFormatter) Output(line LogLineHandler) []byte {
+	if check.IfNil(line) {
+		return nil
+	}
+	if !LogLevel(line.GetLogLevel()).IsAtLeast(lff.logLevel) {
+		return nil
+	}
+
+	return lff.formatter.Output(line)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (lff *levelFilterFormatter) IsInterfaceNil() bool {
+	return lff == nil
+}
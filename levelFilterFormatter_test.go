@@ -0,0 +1,41 @@
+package logger_test
+
+import (
+	"testing"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLevelFilterFormatter_NilFormatterShouldErr(t *testing.T) {
+	t.Parallel()
+
+	lff, err := logger.NewLevelFilterFormatter(nil, logger.LogInfo)
+	assert.True(t, lff.IsInterfaceNil())
+	assert.Equal(t, logger.ErrNilFormatter, err)
+}
+
+func TestLevelFilterFormatter_OutputShouldFilterByLevel(t *testing.T) {
+	t.Parallel()
+
+	formatter := &mock.FormatterStub{
+		OutputCalled: func(line logger.LogLineHandler) []byte {
+			return []byte(line.GetMessage())
+		},
+	}
+	lff, _ := logger.NewLevelFilterFormatter(formatter, logger.LogWarning)
+
+	assert.Nil(t, lff.Output(nil))
+	assert.Nil(t, lff.Output(createLogLineWrapper("info", logger.LogInfo)))
+	assert.Equal(t, []byte("warn"), lff.Output(createLogLineWrapper("warn", logger.LogWarning)))
+	assert.Equal(t, []byte("error"), lff.Output(createLogLineWrapper("error", logger.LogError)))
+}
+
+func createLogLineWrapper(message string, logLevel logger.LogLevel) *logger.LogLineWrapper {
+	line := &logger.LogLineWrapper{}
+	line.Message = message
+	line.LogLevel = int32(logLevel)
+
+	return line
+}
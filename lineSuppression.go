@@ -0,0 +1,575 @@
+package logger
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultSuppressionReportInterval is the interval of the suppressed lines report when none is provided
+const DefaultSuppressionReportInterval = time.Minute
+
+const suppressionLoggerName = "logger/suppression"
+const suppressionReportMessage = "log lines suppressed"
+const maxSampledKeys = 10000
+
+// RateLimit limits the lines output by the loggers matching the pattern (same matching as in the log level patterns)
+// with a token bucket shared by all these loggers: LinesPerSecond tokens are added each second, up to Burst tokens
+// (LinesPerSecond, rounded up, if not provided). When several rate limits match a logger, the last one applies.
+type RateLimit struct {
+	Pattern        string
+	LinesPerSecond float64
+	Burst          int `json:",omitempty"`
+}
+
+// SamplingRule samples the lines output by the loggers matching the pattern: for each (logger, message) pair,
+// the first First lines of a report interval are output and then every Thereafter-th line (none if Thereafter is 0).
+// When several sampling rules match a logger, the last one applies.
+type SamplingRule struct {
+	Pattern    string
+	First      int
+	Thereafter int `json:",omitempty"`
+}
+
+// tokenBucket must be used under its rateLimiter's mutex
+type tokenBucket struct {
+	linesPerSecond float64
+	burst          float64
+	tokens         float64
+	lastRefill     time.Time
+}
+
+func (bucket *tokenBucket) take(now time.Time) bool {
+	elapsed := now.Sub(bucket.lastRefill).Seconds()
+	if elapsed > 0 {
+		bucket.tokens = math.Min(bucket.burst, bucket.tokens+elapsed*bucket.linesPerSecond)
+		bucket.lastRefill = now
+	}
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+	return true
+}
+
+// rateLimiter holds the token bucket of a rate limit and counts, for each logger, the lines it suppressed
+type rateLimiter struct {
+	mut        sync.Mutex
+	bucket     tokenBucket
+	suppressed map[string]uint64
+}
+
+func newRateLimiter(rateLimit RateLimit, now time.Time) *rateLimiter {
+	burst := float64(rateLimit.Burst)
+	if burst == 0 {
+		burst = math.Ceil(rateLimit.LinesPerSecond)
+	}
+
+	return &rateLimiter{
+		bucket: tokenBucket{
+			linesPerSecond: rateLimit.LinesPerSecond,
+			burst:          burst,
+			tokens:         burst,
+			lastRefill:     now,
+		},
+		suppressed: make(map[string]uint64),
+	}
+}
+
+// allow returns true if the line of the provided logger can be output
+func (limiter *rateLimiter) allow(loggerName string, now time.Time) bool {
+	limiter.mut.Lock()
+	defer limiter.mut.Unlock()
+
+	if limiter.bucket.take(now) {
+		return true
+	}
+
+	limiter.suppressed[loggerName]++
+	return false
+}
+
+// collect adds the suppressed lines counts to the provided ones and restarts them
+func (limiter *rateLimiter) collect(counts map[string]uint64) {
+	limiter.mut.Lock()
+	suppressed := limiter.suppressed
+	limiter.suppressed = make(map[string]uint64)
+	limiter.mut.Unlock()
+
+	addSuppressedCounts(counts, suppressed)
+}
+
+type samplingKey struct {
+	loggerName string
+	message    string
+}
+
+// sampler holds the counts of the (logger, message) pairs of a sampling rule and counts, for each logger, the lines
+// it suppressed
+type sampler struct {
+	mut        sync.Mutex
+	rule       SamplingRule
+	counts     map[samplingKey]int
+	suppressed map[string]uint64
+}
+
+func newSampler(rule SamplingRule) *sampler {
+	return &sampler{
+		rule:       rule,
+		counts:     make(map[samplingKey]int),
+		suppressed: make(map[string]uint64),
+	}
+}
+
+// allow returns true if the line of the provided logger and message can be output
+func (s *sampler) allow(loggerName string, message string) bool {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	key := samplingKey{
+		loggerName: loggerName,
+		message:    message,
+	}
+	count, ok := s.counts[key]
+	if !ok && len(s.counts) >= maxSampledKeys {
+		s.counts = make(map[samplingKey]int)
+	}
+	count++
+	s.counts[key] = count
+
+	if count <= s.rule.First {
+		return true
+	}
+	if s.rule.Thereafter > 0 && (count-s.rule.First)%s.rule.Thereafter == 0 {
+		return true
+	}
+
+	s.suppressed[loggerName]++
+	return false
+}
+
+// collect adds the suppressed lines counts to the provided ones and restarts them, along with the sampling
+func (s *sampler) collect(counts map[string]uint64) {
+	s.mut.Lock()
+	suppressed := s.suppressed
+	s.suppressed = make(map[string]uint64)
+	s.counts = make(map[samplingKey]int)
+	s.mut.Unlock()
+
+	addSuppressedCounts(counts, suppressed)
+}
+
+func addSuppressedCounts(dest map[string]uint64, counts map[string]uint64) {
+	for name, count := range counts {
+		dest[name] += count
+	}
+}
+
+// suppressionMatch holds the rate limiter and the sampler applying to a logger, if any
+type suppressionMatch struct {
+	limiter *rateLimiter
+	sampler *sampler
+}
+
+// suppressionRules holds the rate limits and the sampling rules, read by the logging calls without locking: it is
+// replaced as a whole when they change. The rules applying to each logger are looked up once and then cached.
+type suppressionRules struct {
+	rateLimits    []RateLimit
+	samplingRules []SamplingRule
+	limiters      []*rateLimiter
+	samplers      []*sampler
+	matches       sync.Map
+}
+
+func newSuppressionRules(rateLimits []RateLimit, samplingRules []SamplingRule, now time.Time) *suppressionRules {
+	rules := &suppressionRules{
+		rateLimits:    rateLimits,
+		samplingRules: samplingRules,
+		limiters:      make([]*rateLimiter, len(rateLimits)),
+		samplers:      make([]*sampler, len(samplingRules)),
+	}
+	for i, rateLimit := range rateLimits {
+		rules.limiters[i] = newRateLimiter(rateLimit, now)
+	}
+	for i, rule := range samplingRules {
+		rules.samplers[i] = newSampler(rule)
+	}
+
+	return rules
+}
+
+func (rules *suppressionRules) match(loggerName string) suppressionMatch {
+	cached, ok := rules.matches.Load(loggerName)
+	if ok {
+		return cached.(suppressionMatch)
+	}
+
+	match := suppressionMatch{}
+	limitIndex := lastMatchingRateLimit(rules.rateLimits, loggerName)
+	if limitIndex >= 0 {
+		match.limiter = rules.limiters[limitIndex]
+	}
+	ruleIndex := lastMatchingSamplingRule(rules.samplingRules, loggerName)
+	if ruleIndex >= 0 {
+		match.sampler = rules.samplers[ruleIndex]
+	}
+	rules.matches.Store(loggerName, match)
+
+	return match
+}
+
+func (rules *suppressionRules) collect(rateLimited map[string]uint64, sampled map[string]uint64) {
+	for _, limiter := range rules.limiters {
+		limiter.collect(rateLimited)
+	}
+	for _, s := range rules.samplers {
+		s.collect(sampled)
+	}
+}
+
+// lineSuppressor applies the rate limits and the sampling rules and periodically reports the suppressed lines. The
+// mutex guards the changes of the rules and the reports, the logging calls only load the current rules.
+type lineSuppressor struct {
+	mut            sync.Mutex
+	rules          atomic.Value
+	retiredRules   []*suppressionRules
+	reportInterval time.Duration
+	chStop         chan struct{}
+	isActive       int32
+	now            func() time.Time
+	output         func(line *LogLine)
+}
+
+var suppressor = newLineSuppressor()
+
+func newLineSuppressor() *lineSuppressor {
+	ls := &lineSuppressor{
+		now: time.Now,
+		output: func(line *LogLine) {
+			defaultLogOut.Output(line)
+		},
+	}
+	ls.rules.Store(newSuppressionRules(nil, nil, time.Time{}))
+
+	return ls
+}
+
+func (ls *lineSuppressor) getRules() *suppressionRules {
+	return ls.rules.Load().(*suppressionRules)
+}
+
+// SetRateLimits replaces the rate limits. An empty slice removes them.
+func SetRateLimits(rateLimits []RateLimit) error {
+	err := setLineSuppression(rateLimits, GetSamplingRules(), GetSuppressionReportInterval())
+	if err != nil {
+		return err
+	}
+
+	onProfileMutated(ProfileChangeSourceSetRateLimits)
+	return nil
+}
+
+// GetRateLimits returns a copy of the rate limits
+func GetRateLimits() []RateLimit {
+	return copyRateLimits(suppressor.getRules().rateLimits)
+}
+
+// SetSamplingRules replaces the sampling rules. An empty slice removes them.
+func SetSamplingRules(rules []SamplingRule) error {
+	err := setLineSuppression(GetRateLimits(), rules, GetSuppressionReportInterval())
+	if err != nil {
+		return err
+	}
+
+	onProfileMutated(ProfileChangeSourceSetSamplingRules)
+	return nil
+}
+
+// GetSamplingRules returns a copy of the sampling rules
+func GetSamplingRules() []SamplingRule {
+	return copySamplingRules(suppressor.getRules().samplingRules)
+}
+
+// SetSuppressionReportInterval sets the interval of the report line counting, for each logger, the lines suppressed
+// by the rate limits and the sampling rules. A zero interval stands for DefaultSuppressionReportInterval.
+func SetSuppressionReportInterval(interval time.Duration) error {
+	err := setLineSuppression(GetRateLimits(), GetSamplingRules(), interval)
+	if err != nil {
+		return err
+	}
+
+	onProfileMutated(ProfileChangeSourceSetSuppressionReportInterval)
+	return nil
+}
+
+// GetSuppressionReportInterval returns the interval of the suppressed lines report, zero standing for
+// DefaultSuppressionReportInterval
+func GetSuppressionReportInterval() time.Duration {
+	suppressor.mut.Lock()
+	defer suppressor.mut.Unlock()
+
+	return suppressor.reportInterval
+}
+
+func setLineSuppression(rateLimits []RateLimit, rules []SamplingRule, interval time.Duration) error {
+	err := validateLineSuppression(rateLimits, rules, interval)
+	if err != nil {
+		return err
+	}
+	suppressor.set(copyRateLimits(rateLimits), copySamplingRules(rules), interval)
+	return nil
+}
+
+func validateLineSuppression(rateLimits []RateLimit, rules []SamplingRule, interval time.Duration) error {
+	err := validateRateLimits(rateLimits)
+	if err != nil {
+		return err
+	}
+	err = validateSamplingRules(rules)
+	if err != nil {
+		return err
+	}
+
+	return validateSuppressionReportInterval(interval)
+}
+
+func validateRateLimits(rateLimits []RateLimit) error {
+	for i, rateLimit := range rateLimits {
+		err := validateRateLimit(i, rateLimit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateRateLimit(index int, rateLimit RateLimit) error {
+	element := fmt.Sprintf("%s[%d]", fieldRateLimits, index)
+	if len(rateLimit.Pattern) == 0 {
+		return createErrInvalidLineSuppression(element, "empty pattern")
+	}
+	isValidRate := rateLimit.LinesPerSecond > 0 && !math.IsInf(rateLimit.LinesPerSecond, 0)
+	if !isValidRate {
+		return createErrInvalidLineSuppression(element, "the lines per second must be positive")
+	}
+	if rateLimit.Burst < 0 {
+		return createErrInvalidLineSuppression(element, "negative burst")
+	}
+
+	return nil
+}
+
+func validateSamplingRules(rules []SamplingRule) error {
+	for i, rule := range rules {
+		err := validateSamplingRule(i, rule)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateSamplingRule(index int, rule SamplingRule) error {
+	element := fmt.Sprintf("%s[%d]", fieldSamplingRules, index)
+	if len(rule.Pattern) == 0 {
+		return createErrInvalidLineSuppression(element, "empty pattern")
+	}
+	if rule.First < 0 || rule.Thereafter < 0 {
+		return createErrInvalidLineSuppression(element, "negative count")
+	}
+
+	return nil
+}
+
+func validateSuppressionReportInterval(interval time.Duration) error {
+	if interval < 0 {
+		return createErrInvalidLineSuppression(fieldSuppressionReportInterval, "negative interval")
+	}
+
+	return nil
+}
+
+// set replaces the rate limits and the sampling rules, restarting the buckets and the sampling counts. Nothing
+// is changed if they are the same as the current ones (the profiles are applied again and again). The lines
+// suppressed by the replaced rules are counted in the next report.
+func (ls *lineSuppressor) set(rateLimits []RateLimit, rules []SamplingRule, interval time.Duration) {
+	ls.mut.Lock()
+	defer ls.mut.Unlock()
+
+	current := ls.getRules()
+	isUnchanged := reflect.DeepEqual(rateLimits, current.rateLimits) &&
+		reflect.DeepEqual(rules, current.samplingRules) &&
+		interval == ls.reportInterval
+	if isUnchanged {
+		return
+	}
+
+	if len(current.limiters) > 0 || len(current.samplers) > 0 {
+		ls.retiredRules = append(ls.retiredRules, current)
+	}
+	ls.rules.Store(newSuppressionRules(rateLimits, rules, ls.now()))
+
+	isActive := len(rateLimits) > 0 || len(rules) > 0
+	restartReporting := ls.reportInterval != interval || !isActive
+	ls.reportInterval = interval
+	if ls.chStop != nil && restartReporting {
+		close(ls.chStop)
+		ls.chStop = nil
+	}
+	if ls.chStop == nil && isActive {
+		ls.chStop = make(chan struct{})
+		go ls.continuouslyReport(ls.chStop, interval)
+	}
+
+	value := int32(0)
+	if isActive {
+		value = 1
+	}
+	atomic.StoreInt32(&ls.isActive, value)
+}
+
+func (ls *lineSuppressor) continuouslyReport(chStop chan struct{}, interval time.Duration) {
+	if interval == 0 {
+		interval = DefaultSuppressionReportInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ls.report()
+		case <-chStop:
+			ls.report()
+			return
+		}
+	}
+}
+
+// shouldSuppress returns true if the line of the provided logger should be dropped. The PANIC and FATAL lines
+// are never suppressed.
+func shouldSuppress(loggerName string, level LogLevel, message string) bool {
+	return suppressor.shouldSuppress(loggerName, level, message)
+}
+
+// shouldSuppress costs an atomic load while there are neither rate limits nor sampling rules. Otherwise, the lines
+// of the loggers matching no rule only cost a lookup of the cached matches, and the lines of the other loggers lock
+// only the rate limiter and the sampler applying to them.
+func (ls *lineSuppressor) shouldSuppress(loggerName string, level LogLevel, message string) bool {
+	if atomic.LoadInt32(&ls.isActive) == 0 || level.IsAtLeast(LogPanic) {
+		return false
+	}
+
+	match := ls.getRules().match(loggerName)
+	if match.sampler != nil && !match.sampler.allow(loggerName, message) {
+		return true
+	}
+	if match.limiter != nil && !match.limiter.allow(loggerName, ls.now()) {
+		return true
+	}
+
+	return false
+}
+
+func lastMatchingRateLimit(rateLimits []RateLimit, loggerName string) int {
+	index := -1
+	for i, rateLimit := range rateLimits {
+		if isMatchingPattern(loggerName, rateLimit.Pattern) {
+			index = i
+		}
+	}
+
+	return index
+}
+
+func lastMatchingSamplingRule(rules []SamplingRule, loggerName string) int {
+	index := -1
+	for i, rule := range rules {
+		if isMatchingPattern(loggerName, rule.Pattern) {
+			index = i
+		}
+	}
+
+	return index
+}
+
+// report outputs a WARN line counting, for each logger, the lines suppressed since the previous report and
+// restarts the sampling counts
+func (ls *lineSuppressor) report() {
+	ls.mut.Lock()
+	rules := append(ls.retiredRules, ls.getRules())
+	ls.retiredRules = nil
+	ls.mut.Unlock()
+
+	rateLimited := make(map[string]uint64)
+	sampled := make(map[string]uint64)
+	for _, r := range rules {
+		r.collect(rateLimited, sampled)
+	}
+
+	if len(rateLimited) == 0 && len(sampled) == 0 {
+		return
+	}
+
+	args := make([]interface{}, 0)
+	args = appendSuppressedCounts(args, "rate limited", rateLimited)
+	args = appendSuppressedCounts(args, "sampled", sampled)
+	ls.output(newLogLine(suppressionLoggerName, GetCorrelation(), suppressionReportMessage, LogWarning, args...))
+}
+
+func appendSuppressedCounts(args []interface{}, reason string, counts map[string]uint64) []interface{} {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		args = append(args, reason+" "+name, counts[name])
+	}
+
+	return args
+}
+
+func copyRateLimits(rateLimits []RateLimit) []RateLimit {
+	if len(rateLimits) == 0 {
+		return nil
+	}
+
+	result := make([]RateLimit, len(rateLimits))
+	copy(result, rateLimits)
+
+	return result
+}
+
+func copySamplingRules(rules []SamplingRule) []SamplingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]SamplingRule, len(rules))
+	copy(result, rules)
+
+	return result
+}
+
+func formatLineSuppression(rateLimits []RateLimit, rules []SamplingRule) string {
+	formatted := make([]string, 0, len(rateLimits)+len(rules))
+	for _, rateLimit := range rateLimits {
+		formatted = append(formatted, fmt.Sprintf("%s at %g/s", rateLimit.Pattern, rateLimit.LinesPerSecond))
+	}
+	for _, rule := range rules {
+		formatted = append(formatted, fmt.Sprintf("%s first %d then every %d", rule.Pattern, rule.First, rule.Thereafter))
+	}
+
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,196 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	mut     sync.Mutex
+	current time.Time
+}
+
+func (fc *fakeClock) now() time.Time {
+	fc.mut.Lock()
+	defer fc.mut.Unlock()
+
+	return fc.current
+}
+
+func (fc *fakeClock) advance(d time.Duration) {
+	fc.mut.Lock()
+	fc.current = fc.current.Add(d)
+	fc.mut.Unlock()
+}
+
+func newTestLineSuppressor() (*lineSuppressor, *fakeClock, *lineCapture) {
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	capture := &lineCapture{}
+	ls := newLineSuppressor()
+	ls.now = clock.now
+	ls.output = capture.Output
+
+	return ls, clock, capture
+}
+
+func TestLineSuppressor_RateLimits(t *testing.T) {
+	t.Parallel()
+
+	ls, clock, capture := newTestLineSuppressor()
+	require.False(t, ls.shouldSuppress("p2p/host", LogInfo, "message"))
+
+	ls.set([]RateLimit{{Pattern: "*", LinesPerSecond: 100}, {Pattern: "p2p", LinesPerSecond: 2}}, nil, time.Hour)
+	defer ls.set(nil, nil, 0)
+
+	require.False(t, ls.shouldSuppress("p2p/host", LogWarning, "message"))
+	require.False(t, ls.shouldSuppress("p2p/peers", LogWarning, "other message"))
+	require.True(t, ls.shouldSuppress("p2p/host", LogWarning, "message"))
+	require.True(t, ls.shouldSuppress("p2p/peers", LogWarning, "message"))
+	require.False(t, ls.shouldSuppress("p2p/host", LogFatal, "message"))
+	require.False(t, ls.shouldSuppress("process", LogWarning, "message"))
+
+	clock.advance(500 * time.Millisecond)
+	require.False(t, ls.shouldSuppress("p2p/host", LogWarning, "message"))
+	require.True(t, ls.shouldSuppress("p2p/host", LogWarning, "message"))
+
+	// the same rules do not restart the buckets
+	ls.set([]RateLimit{{Pattern: "*", LinesPerSecond: 100}, {Pattern: "p2p", LinesPerSecond: 2}}, nil, time.Hour)
+	require.True(t, ls.shouldSuppress("p2p/host", LogWarning, "message"))
+
+	ls.report()
+	require.Equal(t, 1, len(capture.lines))
+	line := capture.lines[0]
+	require.Equal(t, suppressionLoggerName, line.LoggerName)
+	require.Equal(t, LogWarning, line.LogLevel)
+	require.Equal(t, []interface{}{"rate limited p2p/host", uint64(3), "rate limited p2p/peers", uint64(1)}, line.Args)
+
+	// nothing to report
+	ls.report()
+	require.Equal(t, 1, len(capture.lines))
+}
+
+func TestLineSuppressor_Sampling(t *testing.T) {
+	t.Parallel()
+
+	ls, _, capture := newTestLineSuppressor()
+	ls.set(nil, []SamplingRule{{Pattern: "p2p", First: 2, Thereafter: 3}, {Pattern: "p2p/peers", First: 1}}, time.Hour)
+	defer ls.set(nil, nil, 0)
+
+	output := make([]bool, 0)
+	for i := 0; i < 8; i++ {
+		output = append(output, !ls.shouldSuppress("p2p/host", LogWarning, "message"))
+	}
+	require.Equal(t, []bool{true, true, false, false, true, false, false, true}, output)
+	require.False(t, ls.shouldSuppress("p2p/host", LogWarning, "other message"))
+
+	require.False(t, ls.shouldSuppress("p2p/peers", LogWarning, "message"))
+	require.True(t, ls.shouldSuppress("p2p/peers", LogWarning, "message"))
+
+	// the report restarts the sampling
+	ls.report()
+	require.Equal(t, []interface{}{"sampled p2p/host", uint64(4), "sampled p2p/peers", uint64(1)}, capture.lines[0].Args)
+	require.False(t, ls.shouldSuppress("p2p/peers", LogWarning, "message"))
+}
+
+func TestLineSuppressor_ReplacedRulesShouldBeReported(t *testing.T) {
+	t.Parallel()
+
+	ls, _, capture := newTestLineSuppressor()
+	ls.set([]RateLimit{{Pattern: "p2p", LinesPerSecond: 1}}, nil, time.Hour)
+	defer ls.set(nil, nil, 0)
+
+	require.False(t, ls.shouldSuppress("p2p/host", LogWarning, "message"))
+	require.True(t, ls.shouldSuppress("p2p/host", LogWarning, "message"))
+
+	ls.set([]RateLimit{{Pattern: "p2p", LinesPerSecond: 2}}, nil, time.Hour)
+	require.False(t, ls.shouldSuppress("p2p/host", LogWarning, "message"))
+	require.False(t, ls.shouldSuppress("p2p/host", LogWarning, "message"))
+	require.True(t, ls.shouldSuppress("p2p/host", LogWarning, "message"))
+
+	ls.report()
+	require.Equal(t, []interface{}{"rate limited p2p/host", uint64(2)}, capture.lines[0].Args)
+}
+
+func TestLineSuppressor_ConcurrentLinesAndChanges(t *testing.T) {
+	t.Parallel()
+
+	ls, clock, _ := newTestLineSuppressor()
+	ls.set([]RateLimit{{Pattern: "p2p", LinesPerSecond: 10}}, []SamplingRule{{Pattern: "process", First: 1}}, time.Hour)
+	defer ls.set(nil, nil, 0)
+
+	wg := &sync.WaitGroup{}
+	for _, name := range []string{"p2p/host", "process", "consensus"} {
+		wg.Add(1)
+		go func(loggerName string) {
+			defer wg.Done()
+
+			for i := 0; i < 1000; i++ {
+				suppressed := ls.shouldSuppress(loggerName, LogInfo, "message")
+				if loggerName == "consensus" {
+					require.False(t, suppressed)
+				}
+			}
+		}(name)
+	}
+	for i := 0; i < 10; i++ {
+		clock.advance(time.Second)
+		ls.set([]RateLimit{{Pattern: "p2p", LinesPerSecond: float64(i + 1)}}, nil, time.Hour)
+		ls.report()
+	}
+	wg.Wait()
+}
+
+func TestLineSuppression_InvalidShouldErr(t *testing.T) {
+	t.Parallel()
+
+	err := validateLineSuppression([]RateLimit{{Pattern: "p2p"}}, nil, 0)
+	require.True(t, errors.Is(err, ErrInvalidLineSuppression))
+
+	err = validateLineSuppression([]RateLimit{{Pattern: "", LinesPerSecond: 1}}, nil, 0)
+	require.True(t, errors.Is(err, ErrInvalidLineSuppression))
+
+	err = validateLineSuppression(nil, []SamplingRule{{Pattern: "p2p", First: -1}}, 0)
+	require.True(t, errors.Is(err, ErrInvalidLineSuppression))
+
+	err = validateLineSuppression(nil, nil, -time.Second)
+	require.True(t, errors.Is(err, ErrInvalidLineSuppression))
+
+	profile := Profile{LogLevelPatterns: "*:INFO", RateLimits: []RateLimit{{Pattern: "p2p", LinesPerSecond: -1}}}
+	require.True(t, errors.Is(profile.Apply(), ErrInvalidProfile))
+}
+
+func TestProfile_ShouldCarryTheLineSuppression(t *testing.T) {
+	defer func() {
+		_ = SetRateLimits(nil)
+		_ = SetSamplingRules(nil)
+		_ = SetSuppressionReportInterval(0)
+	}()
+
+	capture := &lineCapture{}
+	log := GetOrCreate("suppression-test")
+	log.logOutput = capture
+
+	profile := GetCurrentProfile()
+	profile.RateLimits = []RateLimit{{Pattern: "suppression-test", LinesPerSecond: 0.001, Burst: 1}}
+	profile.SamplingRules = []SamplingRule{{Pattern: "suppression-test/sampled", First: 1}}
+	profile.SuppressionReportInterval = time.Hour
+
+	// the profiles are sent to the pipes children marshaled
+	data, err := profile.Marshal()
+	require.Nil(t, err)
+	received, err := UnmarshalProfile(data)
+	require.Nil(t, err)
+	require.Equal(t, profile, received)
+
+	err = received.Apply()
+	require.Nil(t, err)
+	require.Equal(t, profile, GetCurrentProfile())
+
+	log.Info("first")
+	log.Info("second")
+	require.Equal(t, 1, len(capture.lines))
+}
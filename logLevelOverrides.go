@@ -0,0 +1,292 @@
+package logger
+
+import (
+	"time"
+)
+
+// LogLevelOverride describes a temporary log level pattern, applied on top of the base log level pattern
+type LogLevelOverride struct {
+	Pattern   string
+	Remaining time.Duration
+}
+
+type logLevelOverride struct {
+	id        uint64
+	pattern   string
+	logLevels []LogLevel
+	patterns  []string
+	expiry    time.Time
+	timer     *time.Timer
+}
+
+// overrideExpiryTolerance is the expiry difference under which two overrides of the same pattern are considered
+// the same, the remaining durations carried by the propagated profiles being slightly behind the actual ones
+const overrideExpiryTolerance = time.Second
+
+// the following variables are protected by logMut
+var activeOverrides []*logLevelOverride
+var lastOverrideID uint64
+
+// baseDefaultLogLevel and baseLoggerLevels hold the log levels resulting from the base log level pattern alone.
+// They are only maintained while there are active overrides: otherwise, the loggers' levels are the base levels.
+var baseDefaultLogLevel LogLevel
+var baseLoggerLevels map[string]LogLevel
+
+// SetLogLevelFor applies the provided log level pattern on top of the base log level pattern (see SetLogLevel)
+// for the provided duration. Once the duration elapses, the override is removed automatically.
+// Overrides stack: they are applied in the order they have been set, a later override taking precedence over
+// an earlier one, on the loggers that both match. Observers are notified when the override is applied
+// and when it expires.
+func SetLogLevelFor(logLevelAndPattern string, ttl time.Duration) error {
+	err := setLogLevelFor(logLevelAndPattern, ttl)
+	if err != nil {
+		return err
+	}
+
+	notifyProfileChange(ProfileChangeSourceSetLogLevelFor)
+	return nil
+}
+
+func setLogLevelFor(logLevelAndPattern string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return ErrInvalidLogLevelOverrideDuration
+	}
+
+	logLevels, patterns, err := ParseLogLevelAndMatchingString(logLevelAndPattern)
+	if err != nil {
+		return err
+	}
+
+	logMut.Lock()
+	defer logMut.Unlock()
+
+	if len(activeOverrides) == 0 {
+		snapshotBaseLogLevels()
+	}
+
+	override := &logLevelOverride{
+		pattern:   logLevelAndPattern,
+		logLevels: logLevels,
+		patterns:  patterns,
+		expiry:    time.Now().Add(ttl),
+	}
+	startLogLevelOverride(override)
+	activeOverrides = append(activeOverrides, override)
+
+	applyEffectiveLogLevels()
+
+	return nil
+}
+
+// startLogLevelOverride identifies the provided override and arms its expiry timer
+func startLogLevelOverride(override *logLevelOverride) {
+	lastOverrideID++
+	id := lastOverrideID
+	override.id = id
+	override.timer = time.AfterFunc(time.Until(override.expiry), func() {
+		expireLogLevelOverride(id)
+	})
+}
+
+func expireLogLevelOverride(id uint64) {
+	logMut.Lock()
+	removed := removeLogLevelOverride(id)
+	logMut.Unlock()
+
+	if removed {
+		notifyProfileChange(ProfileChangeSourceOverrideExpired)
+	}
+}
+
+func removeLogLevelOverride(id uint64) bool {
+	for i, override := range activeOverrides {
+		if override.id != id {
+			continue
+		}
+
+		activeOverrides = append(activeOverrides[:i], activeOverrides[i+1:]...)
+		applyEffectiveLogLevels()
+		if len(activeOverrides) == 0 {
+			baseLoggerLevels = nil
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// GetLogLevelOverrides returns the active log level overrides, in the order they are applied
+func GetLogLevelOverrides() []LogLevelOverride {
+	logMut.RLock()
+	defer logMut.RUnlock()
+
+	return getLogLevelOverrides()
+}
+
+func getLogLevelOverrides() []LogLevelOverride {
+	if len(activeOverrides) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	overrides := make([]LogLevelOverride, 0, len(activeOverrides))
+	for _, override := range activeOverrides {
+		overrides = append(overrides, LogLevelOverride{
+			Pattern:   override.pattern,
+			Remaining: override.expiry.Sub(now),
+		})
+	}
+
+	return overrides
+}
+
+// ClearLogLevelOverrides removes all the active log level overrides, restoring the base log levels
+func ClearLogLevelOverrides() {
+	logMut.Lock()
+	hadOverrides := clearLogLevelOverrides()
+	logMut.Unlock()
+
+	if hadOverrides {
+		notifyProfileChange(ProfileChangeSourceClearLevelOverrides)
+	}
+}
+
+func clearLogLevelOverrides() bool {
+	if len(activeOverrides) == 0 {
+		return false
+	}
+
+	for _, override := range activeOverrides {
+		override.timer.Stop()
+	}
+	activeOverrides = nil
+	applyEffectiveLogLevels()
+	baseLoggerLevels = nil
+
+	return true
+}
+
+// setLogLevelOverrides replaces the active log level overrides with the provided ones, at once, so that the
+// readers never see a partial set. The active overrides are left untouched, timers included, when the provided ones
+// are the same, as it happens each time the profile is propagated.
+func setLogLevelOverrides(overrides []LogLevelOverride) error {
+	now := time.Now()
+	newOverrides := make([]*logLevelOverride, 0, len(overrides))
+	for _, override := range overrides {
+		logLevels, patterns, err := ParseLogLevelAndMatchingString(override.Pattern)
+		if err != nil {
+			return err
+		}
+		if override.Remaining <= 0 {
+			continue
+		}
+
+		newOverrides = append(newOverrides, &logLevelOverride{
+			pattern:   override.Pattern,
+			logLevels: logLevels,
+			patterns:  patterns,
+			expiry:    now.Add(override.Remaining),
+		})
+	}
+
+	logMut.Lock()
+	defer logMut.Unlock()
+
+	if isSameLogLevelOverrides(newOverrides) {
+		return nil
+	}
+
+	hadOverrides := len(activeOverrides) > 0
+	for _, override := range activeOverrides {
+		override.timer.Stop()
+	}
+	if !hadOverrides && len(newOverrides) > 0 {
+		snapshotBaseLogLevels()
+	}
+	for _, override := range newOverrides {
+		startLogLevelOverride(override)
+	}
+
+	activeOverrides = newOverrides
+	if len(activeOverrides) == 0 {
+		activeOverrides = nil
+	}
+	if hadOverrides || len(activeOverrides) > 0 {
+		applyEffectiveLogLevels()
+	}
+	if len(activeOverrides) == 0 {
+		baseLoggerLevels = nil
+	}
+
+	return nil
+}
+
+// isSameLogLevelOverrides returns true if the provided overrides have the patterns of the active ones, in the same
+// order, and expire at the same time, give or take overrideExpiryTolerance
+func isSameLogLevelOverrides(overrides []*logLevelOverride) bool {
+	if len(overrides) != len(activeOverrides) {
+		return false
+	}
+
+	for i, override := range overrides {
+		if override.pattern != activeOverrides[i].pattern {
+			return false
+		}
+
+		drift := override.expiry.Sub(activeOverrides[i].expiry)
+		if drift < -overrideExpiryTolerance || drift > overrideExpiryTolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+func snapshotBaseLogLevels() {
+	baseDefaultLogLevel = defaultLogLevel
+	baseLoggerLevels = make(map[string]LogLevel, len(loggers))
+	for name, log := range loggers {
+		baseLoggerLevels[name] = log.GetLevel()
+	}
+}
+
+// applyEffectiveLogLevels recomputes the levels of all the loggers starting from the base levels and then
+// applying the active overrides, in order
+func applyEffectiveLogLevels() {
+	levels := make(map[string]LogLevel, len(loggers))
+	for name := range loggers {
+		level, ok := baseLoggerLevels[name]
+		if !ok {
+			level = baseDefaultLogLevel
+		}
+		levels[name] = level
+	}
+
+	effectiveDefaultLogLevel := baseDefaultLogLevel
+	for _, override := range activeOverrides {
+		setLogLevelOnLevels(levels, &effectiveDefaultLogLevel, override.logLevels, override.patterns)
+	}
+
+	for name, log := range loggers {
+		log.SetLevel(levels[name])
+	}
+	defaultLogLevel = effectiveDefaultLogLevel
+	updateLowestLogLevel()
+}
+
+func setLogLevelOnLevels(levels map[string]LogLevel, dest *LogLevel, logLevels []LogLevel, patterns []string) {
+	for i := 0; i < len(logLevels); i++ {
+		pattern := patterns[i]
+		logLevel := logLevels[i]
+		for name := range levels {
+			if isMatchingPattern(name, pattern) {
+				levels[name] = logLevel
+			}
+		}
+
+		if pattern == "*" {
+			*dest = logLevel
+		}
+	}
+}
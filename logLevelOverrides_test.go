@@ -0,0 +1,192 @@
+package logger
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type profileChangeCounter struct {
+	numCalls int32
+}
+
+func (counter *profileChangeCounter) OnProfileChanged() {
+	atomic.AddInt32(&counter.numCalls, 1)
+}
+
+func (counter *profileChangeCounter) get() int32 {
+	return atomic.LoadInt32(&counter.numCalls)
+}
+
+func resetLogLevels() {
+	ClearLogLevelOverrides()
+	_ = SetLogLevel("*:INFO")
+}
+
+func TestSetLogLevelFor_InvalidArgumentsShouldErr(t *testing.T) {
+	err := SetLogLevelFor("*:TRACE", 0)
+	require.Equal(t, ErrInvalidLogLevelOverrideDuration, err)
+
+	err = SetLogLevelFor("*:FOO", time.Minute)
+	require.NotNil(t, err)
+	require.Empty(t, GetLogLevelOverrides())
+}
+
+func TestSetLogLevelFor_ShouldApplyAndExpire(t *testing.T) {
+	defer resetLogLevels()
+
+	_ = SetLogLevel("*:WARN")
+	log := GetOrCreate("overrides/expire")
+	counter := &profileChangeCounter{}
+	SubscribeToProfileChange(counter)
+	defer UnsubscribeFromProfileChange(counter)
+
+	err := SetLogLevelFor("overrides:TRACE", 100*time.Millisecond)
+	require.Nil(t, err)
+	require.Equal(t, LogTrace, log.GetLevel())
+	require.Equal(t, "*:WARN", GetLogLevelPattern())
+	require.Eventually(t, func() bool {
+		return counter.get() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		return log.GetLevel() == LogWarning
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return counter.get() == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Empty(t, GetLogLevelOverrides())
+}
+
+func TestSetLogLevelFor_OverlappingOverridesShouldStack(t *testing.T) {
+	defer resetLogLevels()
+
+	_ = SetLogLevel("*:INFO")
+	logA := GetOrCreate("overrides/stack/a")
+	logB := GetOrCreate("overrides/stack/b")
+
+	_ = SetLogLevelFor("*:DEBUG", time.Minute)
+	_ = SetLogLevelFor("overrides/stack/b:TRACE", 100*time.Millisecond)
+	require.Equal(t, LogDebug, logA.GetLevel())
+	require.Equal(t, LogTrace, logB.GetLevel())
+	require.Equal(t, LogDebug, *DefaultLogLevel)
+
+	// the earlier override is still active once the later one expires
+	require.Eventually(t, func() bool {
+		return logB.GetLevel() == LogDebug
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, LogDebug, logA.GetLevel())
+
+	overrides := GetLogLevelOverrides()
+	require.Equal(t, 1, len(overrides))
+	require.Equal(t, "*:DEBUG", overrides[0].Pattern)
+	require.True(t, overrides[0].Remaining > 50*time.Second)
+
+	ClearLogLevelOverrides()
+	require.Equal(t, LogInfo, logA.GetLevel())
+	require.Equal(t, LogInfo, logB.GetLevel())
+	require.Equal(t, LogInfo, *DefaultLogLevel)
+}
+
+func TestSetLogLevelFor_BaseChangesShouldApplyBelowOverrides(t *testing.T) {
+	defer resetLogLevels()
+
+	_ = SetLogLevel("*:INFO")
+	logA := GetOrCreate("overrides/base/a")
+	logB := GetOrCreate("overrides/base/b")
+
+	_ = SetLogLevelFor("overrides/base/a:TRACE", time.Minute)
+	_ = SetLogLevel("*:ERROR")
+	require.Equal(t, LogTrace, logA.GetLevel())
+	require.Equal(t, LogError, logB.GetLevel())
+
+	// loggers created while the overrides are active get the effective default log level
+	logC := GetOrCreate("overrides/base/c")
+	require.Equal(t, LogError, logC.GetLevel())
+
+	ClearLogLevelOverrides()
+	require.Equal(t, LogError, logA.GetLevel())
+	require.Equal(t, LogError, logB.GetLevel())
+	require.Equal(t, LogError, logC.GetLevel())
+}
+
+func TestProfile_ApplyShouldReplaceOverrides(t *testing.T) {
+	defer resetLogLevels()
+
+	log := GetOrCreate("overrides/profile")
+	_ = SetLogLevelFor("*:DEBUG", time.Minute)
+
+	profile := Profile{
+		LogLevelPatterns:  "*:INFO",
+		LogLevelOverrides: []LogLevelOverride{{Pattern: "overrides/profile:TRACE", Remaining: time.Minute}},
+	}
+	data, err := profile.Marshal()
+	require.Nil(t, err)
+	profile, err = UnmarshalProfile(data)
+	require.Nil(t, err)
+
+	err = profile.Apply()
+	require.Nil(t, err)
+	require.Equal(t, LogTrace, log.GetLevel())
+
+	current := GetCurrentProfile()
+	require.Equal(t, 1, len(current.LogLevelOverrides))
+	require.Equal(t, "overrides/profile:TRACE", current.LogLevelOverrides[0].Pattern)
+
+	profile = Profile{LogLevelPatterns: "*:INFO"}
+	err = profile.Apply()
+	require.Nil(t, err)
+	require.Equal(t, LogInfo, log.GetLevel())
+	require.Empty(t, GetLogLevelOverrides())
+}
+
+func TestProfile_ApplyShouldKeepUnchangedOverrides(t *testing.T) {
+	defer resetLogLevels()
+
+	log := GetOrCreate("overrides/propagated")
+	_ = SetLogLevelFor("overrides/propagated:TRACE", time.Minute)
+	logMut.RLock()
+	activeID := activeOverrides[0].id
+	logMut.RUnlock()
+
+	profile := GetCurrentProfile()
+	require.Nil(t, profile.Apply())
+	require.Equal(t, LogTrace, log.GetLevel())
+	logMut.RLock()
+	require.Equal(t, activeID, activeOverrides[0].id)
+	logMut.RUnlock()
+
+	profile.LogLevelOverrides[0].Remaining = time.Hour
+	require.Nil(t, profile.Apply())
+	require.Equal(t, LogTrace, log.GetLevel())
+	logMut.RLock()
+	require.NotEqual(t, activeID, activeOverrides[0].id)
+	logMut.RUnlock()
+}
+
+func TestSetLogLevelOverrides_ConcurrentReadersShouldNeverSeeTheBaseLevels(t *testing.T) {
+	defer resetLogLevels()
+
+	log := GetOrCreate("overrides/swapped")
+	_ = SetLogLevelFor("overrides/swapped:TRACE", time.Minute)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			remaining := time.Minute + time.Duration(i%2)*time.Hour
+			_ = setLogLevelOverrides([]LogLevelOverride{{Pattern: "overrides/swapped:TRACE", Remaining: remaining}})
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+			require.Equal(t, LogTrace, log.GetLevel())
+		}
+	}
+}
@@ -2,10 +2,16 @@ package logger
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 )
 
-// LogLevel defines the priority level of a log line. Trace is the lowest priority level, Error is the highest
+// LogLevel defines the level of a log line. The level's value is carried as is by the log line messages (see
+// LogLineMessage), thus the values of the built-in levels never change. The levels are compared by their priority
+// (see Priority): Trace is the lowest priority level, Fatal is the highest.
 type LogLevel byte
 
 // These constants are the string representation of the package logging levels.
@@ -16,6 +22,8 @@ const (
 	LogWarning LogLevel = 3
 	LogError   LogLevel = 4
 	LogNone    LogLevel = 5
+	LogPanic   LogLevel = 6
+	LogFatal   LogLevel = 7
 )
 
 // Levels contain all defined levels as a slice for an easier iteration
@@ -26,36 +34,277 @@ var Levels = []LogLevel{
 	LogWarning,
 	LogError,
 	LogNone,
+	LogPanic,
+	LogFatal,
 }
 
+// noneLevelPriority is above the priorities of all the other levels, while the unregistered levels come just below it
+const noneLevelPriority = 256
+const unregisteredLevelPriority = noneLevelPriority - 1
+
+const unregisteredLevelPrefix = "LVL"
+const levelNameWidth = 5
+
+var ansiColorRegex = regexp.MustCompile(`^[0-9]+(;[0-9]+)*m$`)
+var levelNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// levelDefinition describes a custom log level
+type levelDefinition struct {
+	name     string
+	priority int
+	color    string
+}
+
+var mutCustomLevels = &sync.RWMutex{}
+var customLevels = make(map[LogLevel]levelDefinition)
+
+// RegisterLogLevel registers a custom log level, such as NOTICE (between INFO and WARN) or VERBOSE (between
+// TRACE and DEBUG). The level's value is the one carried by the log lines, thus the processes exchanging log lines
+// should register the same values, while the priority orders the level among the others, the built-in levels having
+// spaced priorities (see Priority). The name is case insensitive and the color is an ANSI SGR sequence such as "0;35m"
+// used by the console formatter (an empty color stands for the default one). Neither the value, the name nor the
+// priority can be already registered.
+func RegisterLogLevel(name string, level LogLevel, priority int, color string) error {
+	if !levelNameRegex.MatchString(name) || strings.HasPrefix(strings.ToUpper(name), unregisteredLevelPrefix) {
+		return createErrInvalidLogLevelName(name)
+	}
+	if priority < 0 || priority >= unregisteredLevelPriority {
+		return createErrInvalidLogLevelPriority(priority)
+	}
+	if len(color) > 0 && !ansiColorRegex.MatchString(color) {
+		return createErrInvalidLogLevelColor(color)
+	}
+
+	mutCustomLevels.Lock()
+	for _, registered := range registeredLogLevels() {
+		isSameName := strings.EqualFold(levelName(registered), name)
+		if registered == level || isSameName || levelPriority(registered) == priority {
+			mutCustomLevels.Unlock()
+			return createErrLogLevelAlreadyRegistered(name, level)
+		}
+	}
+
+	customLevels[level] = levelDefinition{
+		name:     strings.ToUpper(name),
+		priority: priority,
+		color:    color,
+	}
+	mutCustomLevels.Unlock()
+
+	// the loggers might already be on the level, so far unregistered
+	refreshLowestLogLevel()
+
+	return nil
+}
+
+// UnregisterLogLevel removes a custom log level. The built-in levels can not be removed.
+func UnregisterLogLevel(level LogLevel) {
+	mutCustomLevels.Lock()
+	delete(customLevels, level)
+	mutCustomLevels.Unlock()
+
+	refreshLowestLogLevel()
+}
+
+// RegisteredLogLevels returns the built-in and the custom log levels, sorted by their priority
+func RegisteredLogLevels() []LogLevel {
+	mutCustomLevels.RLock()
+	defer mutCustomLevels.RUnlock()
+
+	return registeredLogLevels()
+}
+
+// registeredLogLevels must be called under mutCustomLevels
+func registeredLogLevels() []LogLevel {
+	levels := make([]LogLevel, 0, len(Levels)+len(customLevels))
+	levels = append(levels, Levels...)
+	for level := range customLevels {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		return levelPriority(levels[i]) < levelPriority(levels[j])
+	})
+
+	return levels
+}
+
+// Priority returns the priority of the level, by which the levels are compared. The built-in levels have the
+// priorities 0 (TRACE), 10 (DEBUG), 20 (INFO), 30 (WARN), 40 (ERROR), 50 (PANIC) and 60 (FATAL), the custom levels
+// the priority they have been registered with, while NONE is above all of them. The unregistered levels come just
+// below NONE, so that they are only filtered out by NONE.
+func (level LogLevel) Priority() int {
+	priority, ok := builtInLevelPriority(level)
+	if ok {
+		return priority
+	}
+
+	mutCustomLevels.RLock()
+	defer mutCustomLevels.RUnlock()
+
+	return levelPriority(level)
+}
+
+// IsAtLeast returns true if the level's priority is greater than or equal to the provided level's priority
+func (level LogLevel) IsAtLeast(other LogLevel) bool {
+	return level.Priority() >= other.Priority()
+}
+
+func builtInLevelPriority(level LogLevel) (int, bool) {
+	switch level {
+	case LogTrace:
+		return 0, true
+	case LogDebug:
+		return 10, true
+	case LogInfo:
+		return 20, true
+	case LogWarning:
+		return 30, true
+	case LogError:
+		return 40, true
+	case LogPanic:
+		return 50, true
+	case LogFatal:
+		return 60, true
+	case LogNone:
+		return noneLevelPriority, true
+	default:
+		return 0, false
+	}
+}
+
+// LogAt outputs a log message on the provided (built-in or custom) log level with optional provided arguments, on the
+// provided logger. The loggers that are not LevelLogger output the message through the method of the built-in level
+// having the highest priority up to the level's one, ERROR at most, so that the Panic and Fatal levels do not panic
+// nor exit.
+func LogAt(log Logger, level LogLevel, message string, args ...interface{}) {
+	log = LoggerWithCallerSkip(log, 1)
+	levelLogger, ok := log.(LevelLogger)
+	if ok {
+		levelLogger.LogAt(level, message, args...)
+		return
+	}
+
+	switch {
+	case level.IsAtLeast(LogError):
+		log.Error(message, args...)
+	case level.IsAtLeast(LogWarning):
+		log.Warn(message, args...)
+	case level.IsAtLeast(LogInfo):
+		log.Info(message, args...)
+	case level.IsAtLeast(LogDebug):
+		log.Debug(message, args...)
+	default:
+		log.Trace(message, args...)
+	}
+}
+
+// levelUpToPriority returns the registered level having the highest priority lower than or equal to the provided one,
+// NONE excepted, or TRACE if the provided priority is lower than all of them
+func levelUpToPriority(priority int) LogLevel {
+	mutCustomLevels.RLock()
+	defer mutCustomLevels.RUnlock()
+
+	found := LogTrace
+	for _, level := range registeredLogLevels() {
+		if level != LogNone && levelPriority(level) <= priority {
+			found = level
+		}
+	}
+
+	return found
+}
+
+// levelPriority must be called under mutCustomLevels
+func levelPriority(level LogLevel) int {
+	priority, ok := builtInLevelPriority(level)
+	if ok {
+		return priority
+	}
+
+	definition, ok := customLevels[level]
+	if ok {
+		return definition.priority
+	}
+
+	return unregisteredLevelPriority
+}
+
+// String returns the name of the level, padded to 5 characters
 func (level LogLevel) String() string {
+	name := level.name()
+	if len(name) < levelNameWidth {
+		name += strings.Repeat(" ", levelNameWidth-len(name))
+	}
+
+	return name
+}
+
+// levelName returns the name of the built-in levels and of the custom ones (which must be read under
+// mutCustomLevels), while the unregistered levels are named after their value: "LVL25"
+func levelName(level LogLevel) string {
 	switch level {
 	case LogTrace:
 		return "TRACE"
 	case LogDebug:
 		return "DEBUG"
 	case LogInfo:
-		return "INFO "
+		return "INFO"
 	case LogWarning:
-		return "WARN "
+		return "WARN"
 	case LogError:
 		return "ERROR"
+	case LogPanic:
+		return "PANIC"
+	case LogFatal:
+		return "FATAL"
 	case LogNone:
-		return "NONE "
-	default:
-		return ""
+		return "NONE"
+	}
+
+	definition, ok := customLevels[level]
+	if ok {
+		return definition.name
 	}
+
+	return unregisteredLevelPrefix + strconv.Itoa(int(level))
+}
+
+func (level LogLevel) name() string {
+	mutCustomLevels.RLock()
+	defer mutCustomLevels.RUnlock()
+
+	return levelName(level)
 }
 
-// GetLogLevel gets the corresponding log level from provided string. The search is case insensitive.
+// GetLogLevel gets the corresponding log level from provided string. The search is case insensitive and it includes
+// the custom levels, while the unregistered levels can be provided by their value, such as "LVL25".
 func GetLogLevel(logLevelAsString string) (LogLevel, error) {
 	providedLogLevelUpperTrimmed := strings.Trim(strings.ToUpper(logLevelAsString), " ")
-	for _, level := range Levels {
-		levelUpperTrimmed := strings.Trim(strings.ToUpper(level.String()), " ")
-		if levelUpperTrimmed == providedLogLevelUpperTrimmed {
+	for _, level := range RegisteredLogLevels() {
+		if level.name() == providedLogLevelUpperTrimmed {
 			return level, nil
 		}
 	}
 
+	if strings.HasPrefix(providedLogLevelUpperTrimmed, unregisteredLevelPrefix) {
+		value, err := strconv.ParseUint(strings.TrimPrefix(providedLogLevelUpperTrimmed, unregisteredLevelPrefix), 10, 8)
+		if err == nil {
+			return LogLevel(value), nil
+		}
+	}
+
 	return LogTrace, fmt.Errorf("unknown log level provided '%s'", logLevelAsString)
 }
+
+// getCustomLevelColor returns the color of a custom level, if any
+func getCustomLevelColor(level LogLevel) (string, bool) {
+	mutCustomLevels.RLock()
+	defer mutCustomLevels.RUnlock()
+
+	definition, ok := customLevels[level]
+	if !ok || len(definition.color) == 0 {
+		return "", false
+	}
+
+	return definition.color, true
+}
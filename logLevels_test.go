@@ -0,0 +1,174 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterLogLevel_InvalidOrAlreadyRegisteredShouldErr(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"", "two words", "1ST", "lvl3", "a:b"} {
+		err := RegisterLogLevel(name, 101, 101, "")
+		require.True(t, errors.Is(err, ErrInvalidLogLevelName), name)
+	}
+
+	for _, priority := range []int{-1, unregisteredLevelPriority, noneLevelPriority} {
+		err := RegisterLogLevel("SEVERE", 101, priority, "")
+		require.True(t, errors.Is(err, ErrInvalidLogLevelPriority), priority)
+	}
+
+	err := RegisterLogLevel("REDDISH", 101, 101, "red")
+	require.True(t, errors.Is(err, ErrInvalidLogLevelColor))
+
+	err = RegisterLogLevel("info", 101, 101, "")
+	require.True(t, errors.Is(err, ErrLogLevelAlreadyRegistered))
+
+	err = RegisterLogLevel("SEVERE", LogError, 101, "")
+	require.True(t, errors.Is(err, ErrLogLevelAlreadyRegistered))
+
+	err = RegisterLogLevel("SEVERE", 101, LogError.Priority(), "")
+	require.True(t, errors.Is(err, ErrLogLevelAlreadyRegistered))
+
+	err = RegisterLogLevel("audit", 102, 102, "1;35m")
+	require.Nil(t, err)
+	defer UnregisterLogLevel(102)
+
+	err = RegisterLogLevel("AUDIT", 103, 103, "")
+	require.True(t, errors.Is(err, ErrLogLevelAlreadyRegistered))
+	err = RegisterLogLevel("other", 102, 103, "")
+	require.True(t, errors.Is(err, ErrLogLevelAlreadyRegistered))
+	err = RegisterLogLevel("other", 103, 102, "")
+	require.True(t, errors.Is(err, ErrLogLevelAlreadyRegistered))
+}
+
+func TestLogLevel_BuiltInValuesShouldBeStable(t *testing.T) {
+	t.Parallel()
+
+	// the values are carried by the log line messages exchanged with the other processes
+	require.Equal(t,
+		[]LogLevel{0, 1, 2, 3, 4, 5, 6, 7},
+		[]LogLevel{LogTrace, LogDebug, LogInfo, LogWarning, LogError, LogNone, LogPanic, LogFatal},
+	)
+
+	levels := RegisteredLogLevels()
+	require.Equal(t, LogTrace, levels[0])
+	require.Equal(t, LogNone, levels[len(levels)-1])
+	for i := 1; i < len(levels); i++ {
+		require.True(t, levels[i-1].Priority() < levels[i].Priority())
+	}
+	require.True(t, LogFatal.IsAtLeast(LogPanic))
+	require.True(t, LogPanic.IsAtLeast(LogError))
+	require.False(t, LogFatal.IsAtLeast(LogNone))
+}
+
+func TestRegisterLogLevel_ShouldBeUnderstoodEverywhere(t *testing.T) {
+	t.Parallel()
+
+	notice := LogLevel(25)
+	err := RegisterLogLevel("Notice", notice, LogInfo.Priority()+5, "0;35m")
+	require.Nil(t, err)
+	defer UnregisterLogLevel(notice)
+
+	level, err := GetLogLevel("notice")
+	require.Nil(t, err)
+	require.Equal(t, notice, level)
+	require.Equal(t, "NOTICE", notice.String())
+	require.Equal(t, "0;35m", getLevelColor(notice))
+
+	levels := RegisteredLogLevels()
+	for i := 1; i < len(levels); i++ {
+		require.True(t, levels[i-1].Priority() < levels[i].Priority())
+	}
+	require.Contains(t, levels, notice)
+	require.True(t, notice.IsAtLeast(LogInfo))
+	require.False(t, notice.IsAtLeast(LogWarning))
+
+	levels, patterns, err := ParseLogLevelAndMatchingString("*:NOTICE,p2p:DEBUG")
+	require.Nil(t, err)
+	require.Equal(t, []LogLevel{notice, LogDebug}, levels)
+	require.Equal(t, []string{"*", "p2p"}, patterns)
+
+	capture := &lineCapture{}
+	log := NewLogger("test", notice, capture)
+	log.Info("filtered out")
+	log.LogAt(notice, "notice")
+	log.Warn("warning")
+	require.Equal(t, 2, len(capture.lines))
+	require.Equal(t, notice, capture.lines[0].LogLevel)
+
+	// the received lines
+	line := &LogLineWrapper{}
+	line.LogLevel = int32(notice)
+	line.Message = "message"
+	require.True(t, strings.HasPrefix(string((&PlainFormatter{}).Output(line)), "NOTICE["))
+}
+
+func TestLogLevel_UnregisteredLevelsShouldBeNamedAfterTheirValue(t *testing.T) {
+	t.Parallel()
+
+	level := LogLevel(33)
+	require.Equal(t, "LVL33", level.String())
+	require.True(t, level.IsAtLeast(LogFatal))
+	require.False(t, level.IsAtLeast(LogNone))
+	require.Equal(t, ansiRegularBlack, getLevelColor(level))
+
+	parsed, err := GetLogLevel("lvl33")
+	require.Nil(t, err)
+	require.Equal(t, level, parsed)
+
+	_, err = GetLogLevel("LVL256")
+	require.NotNil(t, err)
+
+	require.Equal(t, "INFO ", LogInfo.String())
+	require.Equal(t, "NONE ", LogNone.String())
+}
+
+type methodsRecorder struct {
+	methods []string
+}
+
+func (mr *methodsRecorder) Trace(_ string, _ ...interface{}) {
+	mr.methods = append(mr.methods, "Trace")
+}
+func (mr *methodsRecorder) Debug(_ string, _ ...interface{}) {
+	mr.methods = append(mr.methods, "Debug")
+}
+func (mr *methodsRecorder) Info(_ string, _ ...interface{}) { mr.methods = append(mr.methods, "Info") }
+func (mr *methodsRecorder) Warn(_ string, _ ...interface{}) { mr.methods = append(mr.methods, "Warn") }
+func (mr *methodsRecorder) Error(_ string, _ ...interface{}) {
+	mr.methods = append(mr.methods, "Error")
+}
+func (mr *methodsRecorder) LogIfError(_ error, _ ...interface{}) {}
+func (mr *methodsRecorder) Log(_ *LogLine)                       {}
+func (mr *methodsRecorder) SetLevel(_ LogLevel)                  {}
+func (mr *methodsRecorder) GetLevel() LogLevel                   { return LogTrace }
+func (mr *methodsRecorder) IsInterfaceNil() bool                 { return mr == nil }
+
+func TestLogAt_ShouldOutputOnTheProvidedLevel(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	log := NewLogger("test", LogTrace, capture)
+	LogAt(log, LogLevel(104), "unregistered")
+	LogAt(log.WithTrace("trace", "span"), LogFatal, "fatal")
+
+	require.Equal(t, 2, len(capture.lines))
+	require.Equal(t, LogLevel(104), capture.lines[0].LogLevel)
+	require.Equal(t, LogFatal, capture.lines[1].LogLevel)
+	require.Equal(t, "trace", capture.lines[1].TraceID)
+}
+
+func TestLogAt_ShouldFallBackOnTheBuiltInMethods(t *testing.T) {
+	t.Parallel()
+
+	recorder := &methodsRecorder{}
+	for _, level := range []LogLevel{LogTrace, LogDebug, LogInfo, LogWarning, LogError, LogPanic, LogFatal} {
+		LogAt(recorder, level, "message")
+	}
+
+	require.Equal(t, []string{"Trace", "Debug", "Info", "Warn", "Error", "Error", "Error"}, recorder.methods)
+}
@@ -15,6 +15,10 @@ type LogLine struct {
 	LogLevel    LogLevel
 	Args        []interface{}
 	Timestamp   time.Time
+	TraceID     string
+	SpanID      string
+	Caller      *proto.LogCallerMessage
+	Stack       []string
 }
 
 func newLogLine(loggerName string, correlation proto.LogCorrelationMessage, message string, logLevel LogLevel, args ...interface{}) *LogLine {
@@ -28,6 +32,30 @@ func newLogLine(loggerName string, correlation proto.LogCorrelationMessage, mess
 	}
 }
 
+// RecoverLogLine converts a received (unmarshalized) log line wrapper back into a log line
+func RecoverLogLine(wrapper *LogLineWrapper) *LogLine {
+	logLine := &LogLine{
+		LoggerName:  wrapper.LoggerName,
+		Correlation: wrapper.Correlation,
+		Message:     wrapper.Message,
+		LogLevel:    LogLevel(wrapper.LogLevel),
+		Args:        make([]interface{}, len(wrapper.Args)),
+		Timestamp:   time.Unix(0, wrapper.Timestamp),
+		TraceID:     wrapper.TraceID,
+		SpanID:      wrapper.SpanID,
+		Caller:      wrapper.Caller,
+		Stack:       wrapper.Stack,
+	}
+
+	for i, str := range wrapper.Args {
+		logLine.Args[i] = str
+	}
+
+	return logLine
+}
+
+var _ LogLineDetailsHandler = (*LogLineWrapper)(nil)
+
 // LogLineWrapper is a wrapper over protobuf.LogLineMessage that enables the structure to be used with
 // protobuf marshaller
 type LogLineWrapper struct {
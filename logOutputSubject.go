@@ -1,8 +1,8 @@
 package logger
 
 import (
-	"fmt"
 	"io"
+	"reflect"
 	"sync"
 
 	"github.com/kalyan3104/dme-logger-go/check"
@@ -31,9 +31,18 @@ func NewLogOutputSubject() *logOutputSubject {
 func (los *logOutputSubject) Output(line *LogLine) {
 	los.mutObservers.RLock()
 
-	convertedLine := los.convertLogLine(line)
+	// the line is converted once for each distinct argument renderer of the observers
+	defaultRenderer := GetArgRenderer()
+	renderers := []ArgRenderer{defaultRenderer}
+	convertedLines := []LogLineHandler{convertLogLine(line, defaultRenderer)}
 	for i := 0; i < len(los.writers); i++ {
 		format := los.formatters[i]
+		convertedLine := convertedLines[0]
+		provider, ok := format.(ArgRendererProvider)
+		if ok && !check.IfNil(provider.ArgRenderer()) {
+			convertedLine, renderers, convertedLines = findConvertedLine(line, provider.ArgRenderer(), renderers, convertedLines)
+		}
+
 		buff := format.Output(convertedLine)
 		_, _ = los.writers[i].Write(buff)
 	}
@@ -41,7 +50,29 @@ func (los *logOutputSubject) Output(line *LogLine) {
 	los.mutObservers.RUnlock()
 }
 
-func (los *logOutputSubject) convertLogLine(logLine *LogLine) LogLineHandler {
+// findConvertedLine returns the line already converted with the provided renderer, if any, or else converts it. The
+// renderers of uncomparable types (such as the func types) can not be compared, so the line is converted again for
+// each of their observers.
+func findConvertedLine(
+	line *LogLine,
+	renderer ArgRenderer,
+	renderers []ArgRenderer,
+	convertedLines []LogLineHandler,
+) (LogLineHandler, []ArgRenderer, []LogLineHandler) {
+	if reflect.TypeOf(renderer).Comparable() {
+		for i := range renderers {
+			if renderers[i] == renderer {
+				return convertedLines[i], renderers, convertedLines
+			}
+		}
+	}
+
+	convertedLine := convertLogLine(line, renderer)
+
+	return convertedLine, append(renderers, renderer), append(convertedLines, convertedLine)
+}
+
+func convertLogLine(logLine *LogLine, renderer ArgRenderer) LogLineHandler {
 	if logLine == nil {
 		return nil
 	}
@@ -53,18 +84,29 @@ func (los *logOutputSubject) convertLogLine(logLine *LogLine) LogLineHandler {
 	line.LogLevel = int32(logLine.LogLevel)
 	line.Args = make([]string, len(logLine.Args))
 	line.Timestamp = logLine.Timestamp.UnixNano()
+	line.TraceID = logLine.TraceID
+	line.SpanID = logLine.SpanID
+	line.Caller = logLine.Caller
+	line.Stack = logLine.Stack
 
-	mutDisplayByteSlice.RLock()
-	displayHandler := displayByteSlice
-	mutDisplayByteSlice.RUnlock()
-
+	// the arguments are redacted here, so that no formatter, observer or parent process sees the sensitive data
+	policy := getRedactionPolicy()
+	keyedRenderer, isKeyed := renderer.(KeyedArgRenderer)
+	key := ""
 	for i, obj := range logLine.Args {
-		switch obj := obj.(type) {
-		case []byte:
-			line.Args[i] = displayHandler(obj)
-		default:
-			line.Args[i] = fmt.Sprintf("%v", obj)
+		isValue := i%2 == 1
+		if !isValue {
+			key = ""
+		}
+
+		var value string
+		if isKeyed {
+			value = keyedRenderer.RenderKeyed(key, obj)
+		} else {
+			value = renderer.Render(obj)
 		}
+		line.Args[i] = policy.redactArg(obj, value, key, isValue)
+		key = value
 	}
 
 	return line
@@ -108,6 +150,133 @@ func (los *logOutputSubject) RemoveObserver(w io.Writer) error {
 	return ErrWriterNotFound
 }
 
+// Observers returns copies of the containing writers and formatters lists
+func (los *logOutputSubject) Observers() ([]io.Writer, []Formatter) {
+	los.mutObservers.RLock()
+	defer los.mutObservers.RUnlock()
+
+	writers := make([]io.Writer, len(los.writers))
+	copy(writers, los.writers)
+	formatters := make([]Formatter, len(los.formatters))
+	copy(formatters, los.formatters)
+
+	return writers, formatters
+}
+
+// ReplaceObservers atomically replaces all the observers with the provided ones. The writers and formatters
+// are paired by their position in the provided slices.
+func (los *logOutputSubject) ReplaceObservers(writers []io.Writer, formatters []Formatter) error {
+	if len(writers) != len(formatters) {
+		return ErrObserversLengthMismatch
+	}
+	for i := range writers {
+		if writers[i] == nil {
+			return ErrNilWriter
+		}
+		if check.IfNil(formatters[i]) {
+			return ErrNilFormatter
+		}
+	}
+
+	newWriters := make([]io.Writer, len(writers))
+	copy(newWriters, writers)
+	newFormatters := make([]Formatter, len(formatters))
+	copy(newFormatters, formatters)
+
+	los.mutObservers.Lock()
+	los.writers = newWriters
+	los.formatters = newFormatters
+	los.mutObservers.Unlock()
+
+	return nil
+}
+
+// SwapObservers atomically removes the provided old observers (each one matched by both its writer and its
+// formatter, the missing ones being skipped) and adds the new ones, which take the place of the first removed
+// observer or else are appended. The other observers are kept.
+func (los *logOutputSubject) SwapObservers(
+	oldWriters []io.Writer,
+	oldFormatters []Formatter,
+	writers []io.Writer,
+	formatters []Formatter,
+) error {
+	if len(oldWriters) != len(oldFormatters) || len(writers) != len(formatters) {
+		return ErrObserversLengthMismatch
+	}
+	for i := range writers {
+		if writers[i] == nil {
+			return ErrNilWriter
+		}
+		if check.IfNil(formatters[i]) {
+			return ErrNilFormatter
+		}
+	}
+
+	los.mutObservers.Lock()
+	defer los.mutObservers.Unlock()
+
+	newWriters := make([]io.Writer, 0, len(los.writers)+len(writers))
+	newFormatters := make([]Formatter, 0, len(los.formatters)+len(formatters))
+	removed := make([]bool, len(oldWriters))
+	inserted := false
+	for i := range los.writers {
+		if !removeObserver(los.writers[i], los.formatters[i], oldWriters, oldFormatters, removed) {
+			newWriters = append(newWriters, los.writers[i])
+			newFormatters = append(newFormatters, los.formatters[i])
+			continue
+		}
+		if !inserted {
+			newWriters = append(newWriters, writers...)
+			newFormatters = append(newFormatters, formatters...)
+			inserted = true
+		}
+	}
+	if !inserted {
+		newWriters = append(newWriters, writers...)
+		newFormatters = append(newFormatters, formatters...)
+	}
+
+	los.writers = newWriters
+	los.formatters = newFormatters
+
+	return nil
+}
+
+// removeObserver marks as removed the first not yet removed old observer matching the provided one and returns
+// whether there was one. The formatters of uncomparable types are never matched.
+func removeObserver(w io.Writer, format Formatter, oldWriters []io.Writer, oldFormatters []Formatter, removed []bool) bool {
+	if !reflect.TypeOf(format).Comparable() {
+		return false
+	}
+
+	for i := range oldWriters {
+		if !removed[i] && oldWriters[i] == w && oldFormatters[i] == format {
+			removed[i] = true
+			return true
+		}
+	}
+
+	return false
+}
+
+// Flush flushes the writers buffering the log lines (those implementing Flusher or having a Sync method, such as
+// os.File), returning the first encountered error. As for Output, the observers cannot be replaced meanwhile, so
+// that the replaced writers can be closed as soon as they are no longer observers.
+func (los *logOutputSubject) Flush() error {
+	los.mutObservers.RLock()
+	defer los.mutObservers.RUnlock()
+
+	var firstErr error
+	for _, w := range los.writers {
+		err := flushWriter(w)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
 // ClearObservers clears the observers lists
 func (los *logOutputSubject) ClearObservers() {
 	los.mutObservers.Lock()
@@ -1,6 +1,7 @@
 package logger_test
 
 import (
+	"io"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -227,3 +228,122 @@ func TestLogOutputSubject_ClearObservers(t *testing.T) {
 	obs, _ = los.Observers()
 	assert.Equal(t, 0, len(obs))
 }
+
+//------- ReplaceObservers
+
+func TestLogOutputSubject_ReplaceObserversInvalidArgumentsShouldError(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	w := &mock.WriterStub{}
+	_ = los.AddObserver(w, &mock.FormatterStub{})
+
+	err := los.ReplaceObservers([]io.Writer{&mock.WriterStub{}}, nil)
+	assert.Equal(t, logger.ErrObserversLengthMismatch, err)
+
+	err = los.ReplaceObservers([]io.Writer{nil}, []logger.Formatter{&mock.FormatterStub{}})
+	assert.Equal(t, logger.ErrNilWriter, err)
+
+	err = los.ReplaceObservers([]io.Writer{&mock.WriterStub{}}, []logger.Formatter{nil})
+	assert.Equal(t, logger.ErrNilFormatter, err)
+
+	writers, _ := los.Observers()
+	assert.Equal(t, []io.Writer{w}, writers)
+}
+
+func TestLogOutputSubject_ReplaceObserversShouldWork(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(&mock.WriterStub{}, &mock.FormatterStub{})
+
+	w1, w2 := &mock.WriterStub{}, &mock.WriterStub{}
+	f1, f2 := &mock.FormatterStub{}, &mock.FormatterStub{}
+	err := los.ReplaceObservers([]io.Writer{w1, w2}, []logger.Formatter{f1, f2})
+	assert.Nil(t, err)
+
+	writers, formatters := los.Observers()
+	assert.Equal(t, []io.Writer{w1, w2}, writers)
+	assert.Equal(t, []logger.Formatter{f1, f2}, formatters)
+}
+
+func TestLogOutputSubject_SwapObserversShouldKeepTheOtherObservers(t *testing.T) {
+	t.Parallel()
+
+	los := logger.NewLogOutputSubject()
+	owned, other := &mock.WriterStub{}, &mock.WriterStub{}
+	ownedFormatter, otherFormatter := &mock.FormatterStub{}, &mock.FormatterStub{}
+	_ = los.AddObserver(owned, ownedFormatter)
+	_ = los.AddObserver(other, otherFormatter)
+	_ = los.AddObserver(owned, otherFormatter)
+
+	w1, w2 := &mock.WriterStub{}, &mock.WriterStub{}
+	f1, f2 := &mock.FormatterStub{}, &mock.FormatterStub{}
+	err := los.SwapObservers(
+		[]io.Writer{owned, &mock.WriterStub{}},
+		[]logger.Formatter{ownedFormatter, &mock.FormatterStub{}},
+		[]io.Writer{w1, w2},
+		[]logger.Formatter{f1, f2},
+	)
+	assert.Nil(t, err)
+
+	// the stubs are compared by pointer, as they are deeply equal
+	writers, formatters := los.Observers()
+	assert.Equal(t, 4, len(writers))
+	assert.True(t, writers[0] == w1 && writers[1] == w2 && writers[2] == other && writers[3] == owned)
+	assert.True(t, formatters[0] == f1 && formatters[1] == f2 && formatters[2] == otherFormatter && formatters[3] == otherFormatter)
+
+	err = los.SwapObservers(nil, nil, []io.Writer{w1}, []logger.Formatter{nil})
+	assert.Equal(t, logger.ErrNilFormatter, err)
+	err = los.SwapObservers([]io.Writer{w1, w2}, []logger.Formatter{f1, f2}, nil, nil)
+	assert.Nil(t, err)
+
+	writers, _ = los.Observers()
+	assert.Equal(t, 2, len(writers))
+	assert.True(t, writers[0] == other && writers[1] == owned)
+}
+
+type blockingFlushWriter struct {
+	chFlushing chan struct{}
+	chUnblock  chan struct{}
+}
+
+func (writer *blockingFlushWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (writer *blockingFlushWriter) Flush() error {
+	close(writer.chFlushing)
+	<-writer.chUnblock
+	return nil
+}
+
+func TestLogOutputSubject_ReplaceObserversShouldWaitForTheFlush(t *testing.T) {
+	t.Parallel()
+
+	writer := &blockingFlushWriter{
+		chFlushing: make(chan struct{}),
+		chUnblock:  make(chan struct{}),
+	}
+	los := logger.NewLogOutputSubject()
+	_ = los.AddObserver(writer, &mock.FormatterStub{})
+
+	go func() {
+		_ = los.Flush()
+	}()
+	<-writer.chFlushing
+
+	var replaced int32
+	chReplaced := make(chan struct{})
+	go func() {
+		_ = los.ReplaceObservers([]io.Writer{&mock.WriterStub{}}, []logger.Formatter{&mock.FormatterStub{}})
+		atomic.StoreInt32(&replaced, 1)
+		close(chReplaced)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&replaced))
+
+	close(writer.chUnblock)
+	<-chReplaced
+}
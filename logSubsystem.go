@@ -3,20 +3,32 @@ package logger
 import (
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+
+	"github.com/kalyan3104/dme-logger-go/check"
 )
 
 var logMut = &sync.RWMutex{}
 var loggers map[string]*logger
-var defaultLogOut LogOutputHandler
+var defaultLogOut *logOutputSubject
 var defaultLogLevel = LogInfo
 var logPattern = ""
 var withLoggerName bool
 
+// lowestLogLevel caches the lowest priority level among the levels of the loggers and the level of the loggers not
+// created yet, so that it can be read without locking (as the slog handlers do for each record)
+var mutLowestLogLevel = &sync.Mutex{}
+var lowestLogLevel = int32(LogInfo)
+
 var mutDisplayByteSlice = &sync.RWMutex{}
 var displayByteSlice func(slice []byte) string
 
+var mutArgRenderer = &sync.RWMutex{}
+var argRenderer ArgRenderer
+
 func init() {
 	logPattern = "*:INFO"
 	loggers = make(map[string]*logger)
@@ -24,6 +36,7 @@ func init() {
 	_ = defaultLogOut.AddObserver(os.Stdout, &ConsoleFormatter{})
 
 	displayByteSlice = ToHex
+	argRenderer = NewArgRendererRegistry()
 }
 
 // GetOrCreate returns a log based on the name provided, generating a new log if there is no log with provided name
@@ -34,6 +47,10 @@ func GetOrCreate(name string) *logger {
 	loggerFromMap, ok := loggers[name]
 	if !ok {
 		loggerFromMap = NewLogger(name, defaultLogLevel, defaultLogOut)
+		loggerFromMap.setCallerCapture(isCallerCaptureMatching(name))
+		loggerFromMap.setStackCapture(isStackCaptureMatching(name))
+		// the new logger has the default log level, already taken into account by the lowest level
+		loggerFromMap.isRegistered = true
 		loggers[name] = loggerFromMap
 	}
 
@@ -51,21 +68,37 @@ func GetOrCreate(name string) *logger {
 // Example: *:INFO,p2p:ERROR,*:DEBUG,data:INFO will result in having the data package logger(s) on INFO log level
 // and all other packages on DEBUG level
 func SetLogLevel(logLevelAndPattern string) error {
+	err := setLogLevel(logLevelAndPattern)
+	if err != nil {
+		return err
+	}
+
+	onProfileMutated(ProfileChangeSourceSetLogLevel)
+	return nil
+}
+
+func setLogLevel(logLevelAndPattern string) error {
 	logLevels, patterns, err := ParseLogLevelAndMatchingString(logLevelAndPattern)
 	if err != nil {
 		return err
 	}
 
 	logMut.Lock()
-	setLogLevelOnMap(loggers, &defaultLogLevel, logLevels, patterns)
+	if len(activeOverrides) == 0 {
+		setLogLevelOnMap(loggers, &defaultLogLevel, logLevels, patterns)
+		updateLowestLogLevel()
+	} else {
+		setLogLevelOnBase(logLevels, patterns)
+		applyEffectiveLogLevels()
+	}
 	logPattern = logLevelAndPattern
 	logMut.Unlock()
 
 	return nil
 }
 
-// GetLogLevelPattern returns the last set log level pattern.
-// The format returned is MATCHING_STRING1:LOG_LEVEL1,MATCHING_STRING2:LOG_LEVEL2".
+// GetLogLevelPattern returns the last set log level pattern. Temporary overrides (see SetLogLevelFor) are not
+// included. The format returned is MATCHING_STRING1:LOG_LEVEL1,MATCHING_STRING2:LOG_LEVEL2".
 func GetLogLevelPattern() string {
 	logMut.RLock()
 	defer logMut.RUnlock()
@@ -87,8 +120,27 @@ func GetLoggerLogLevel(loggerName string) LogLevel {
 	return logLevel
 }
 
+// GetLoggerNames returns the sorted names of all the loggers created so far
+func GetLoggerNames() []string {
+	logMut.RLock()
+	defer logMut.RUnlock()
+
+	names := make([]string, 0, len(loggers))
+	for name := range loggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 // ToggleLoggerName enables / disables logger name
 func ToggleLoggerName(enable bool) {
+	toggleLoggerName(enable)
+	onProfileMutated(ProfileChangeSourceToggleLoggerName)
+}
+
+func toggleLoggerName(enable bool) {
 	logMut.Lock()
 	withLoggerName = enable
 	logMut.Unlock()
@@ -124,13 +176,68 @@ func ClearLogObservers() {
 	defaultLogOut.ClearObservers()
 }
 
+// GetLogObservers returns the writers and formatters of the default log output subject
+func GetLogObservers() ([]io.Writer, []Formatter) {
+	return defaultLogOut.Observers()
+}
+
+// ReplaceLogObservers atomically replaces the observers of the default log output subject
+func ReplaceLogObservers(writers []io.Writer, formatters []Formatter) error {
+	return defaultLogOut.ReplaceObservers(writers, formatters)
+}
+
+// SwapLogObservers atomically replaces the provided observers of the default log output subject with the new ones,
+// keeping the other observers (see ReplaceLogObservers for replacing all of them)
+func SwapLogObservers(oldWriters []io.Writer, oldFormatters []Formatter, writers []io.Writer, formatters []Formatter) error {
+	return defaultLogOut.SwapObservers(oldWriters, oldFormatters, writers, formatters)
+}
+
+// getLowestLogLevel returns the lowest priority level among the levels of the loggers and the level of the loggers
+// not created yet
+func getLowestLogLevel() LogLevel {
+	return LogLevel(atomic.LoadInt32(&lowestLogLevel))
+}
+
+// updateLowestLogLevel recomputes the lowest level once the levels of the loggers are changed. Must be called
+// under logMut.
+func updateLowestLogLevel() {
+	mutLowestLogLevel.Lock()
+	defer mutLowestLogLevel.Unlock()
+
+	lowest := defaultLogLevel
+	for _, log := range loggers {
+		level := log.GetLevel()
+		if !level.IsAtLeast(lowest) {
+			lowest = level
+		}
+	}
+	atomic.StoreInt32(&lowestLogLevel, int32(lowest))
+}
+
+// refreshLowestLogLevel recomputes the lowest level once the priorities of the levels are changed
+func refreshLowestLogLevel() {
+	logMut.RLock()
+	updateLowestLogLevel()
+	logMut.RUnlock()
+}
+
+// lowerLowestLogLevel lowers the lowest level to the level set on a single logger, if lower. A raised level is
+// taken into account by the next recomputation, meanwhile the lowest level is only lower than needed.
+func lowerLowestLogLevel(level LogLevel) {
+	mutLowestLogLevel.Lock()
+	defer mutLowestLogLevel.Unlock()
+
+	if !level.IsAtLeast(getLowestLogLevel()) {
+		atomic.StoreInt32(&lowestLogLevel, int32(level))
+	}
+}
+
 func setLogLevelOnMap(loggers map[string]*logger, dest *LogLevel, logLevels []LogLevel, patterns []string) {
 	for i := 0; i < len(logLevels); i++ {
 		pattern := patterns[i]
 		logLevel := logLevels[i]
 		for name, log := range loggers {
-			isMatching := pattern == "*" || strings.Contains(name, pattern)
-			if isMatching {
+			if isMatchingPattern(name, pattern) {
 				log.SetLevel(logLevel)
 			}
 		}
@@ -141,6 +248,22 @@ func setLogLevelOnMap(loggers map[string]*logger, dest *LogLevel, logLevels []Lo
 	}
 }
 
+// setLogLevelOnBase changes the base log levels while there are active log level overrides
+func setLogLevelOnBase(logLevels []LogLevel, patterns []string) {
+	for name := range loggers {
+		_, ok := baseLoggerLevels[name]
+		if !ok {
+			baseLoggerLevels[name] = baseDefaultLogLevel
+		}
+	}
+
+	setLogLevelOnLevels(baseLoggerLevels, &baseDefaultLogLevel, logLevels, patterns)
+}
+
+func isMatchingPattern(loggerName string, pattern string) bool {
+	return pattern == "*" || strings.Contains(loggerName, pattern)
+}
+
 // ParseLogLevelAndMatchingString can parse a string in the form "MATCHING_STRING1:LOG_LEVEL1,MATCHING_STRING2:LOG_LEVEL2" into its
 // corresponding log level and matching string. Errors if something goes wrong.
 // For example, having the parameter "DEBUG|process" will set the DEBUG level on all loggers that will contain
@@ -191,6 +314,36 @@ func SetDisplayByteSlice(f func(slice []byte) string) error {
 	return nil
 }
 
+// GetDisplayByteSlice returns the current converter function from byte slice to string
+func GetDisplayByteSlice() func(slice []byte) string {
+	mutDisplayByteSlice.RLock()
+	defer mutDisplayByteSlice.RUnlock()
+
+	return displayByteSlice
+}
+
+// SetArgRenderer sets the default renderer of the log arguments, used by the observers whose formatter does not
+// provide its own (see NewArgRenderingFormatter)
+func SetArgRenderer(renderer ArgRenderer) error {
+	if check.IfNil(renderer) {
+		return ErrNilArgRenderer
+	}
+
+	mutArgRenderer.Lock()
+	argRenderer = renderer
+	mutArgRenderer.Unlock()
+
+	return nil
+}
+
+// GetArgRenderer returns the default renderer of the log arguments
+func GetArgRenderer() ArgRenderer {
+	mutArgRenderer.RLock()
+	defer mutArgRenderer.RUnlock()
+
+	return argRenderer
+}
+
 // DisplayByteSlice converts the provided byte slice to its string representation using
 // displayByteSlice function pointer
 func DisplayByteSlice(slice []byte) string {
@@ -1,6 +1,7 @@
 package logger_test
 
 import (
+	"sort"
 	"testing"
 
 	logger "github.com/kalyan3104/dme-logger-go"
@@ -84,3 +85,14 @@ func TestGetLoggerLogLevel(t *testing.T) {
 	// rollback to the default value
 	_ = logger.SetLogLevel("*:INFO")
 }
+
+func TestGetLoggerNames(t *testing.T) {
+	_ = logger.GetOrCreate("names/b")
+	_ = logger.GetOrCreate("names/a")
+
+	names := logger.GetLoggerNames()
+
+	assert.Contains(t, names, "names/a")
+	assert.Contains(t, names, "names/b")
+	assert.True(t, sort.StringsAreSorted(names))
+}
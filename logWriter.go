@@ -0,0 +1,192 @@
+package logger
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/kalyan3104/dme-logger-go/check"
+)
+
+// maxPartialLineSize is the size of an unterminated line after which it is output anyway
+const maxPartialLineSize = 64 * 1024
+
+var bracketLevelRegex = regexp.MustCompile(`^\[([A-Za-z][A-Za-z0-9_]*)\]`)
+var keyValueLevelRegex = regexp.MustCompile(`^(?:level|lvl)=("?)([A-Za-z][A-Za-z0-9_]*)("?)(?:\s|$)`)
+
+// lineHeaderRegex matches an element of the header written before the level by the std log package and the
+// similar libraries: a date, a time, a prefix or a source location ending with a colon, a bracketed tag or a
+// logfmt time pair
+var lineHeaderRegex = regexp.MustCompile(
+	`^(?:\d{4}/\d{2}/\d{2}|\d{2}:\d{2}:\d{2}(?:\.\d+)?|[^\s\[\]=]+:|\[[^\]]*\]|(?:time|ts)=(?:"[^"]*"|\S+))(?:\s+|$)`,
+)
+
+// levelAliases holds the level names used by other logging libraries which are not log level names
+var levelAliases = map[string]LogLevel{
+	"WARNING":  LogWarning,
+	"ERR":      LogError,
+	"CRIT":     LogError,
+	"CRITICAL": LogError,
+}
+
+var _ Flusher = (*logWriter)(nil)
+
+// logWriter is an io.Writer outputting each written line as a log line, so that the standard log package and the
+// libraries writing their own logs on a provided writer are subject to the log level patterns
+type logWriter struct {
+	log          Logger
+	defaultLevel LogLevel
+	detectLevel  bool
+	mut          sync.Mutex
+	partialLine  []byte
+}
+
+// NewLogWriter creates an io.Writer outputting each written line on the provided logger, at the provided default
+// level. If detectLevel is set, a level starting the line, such as "[WARN]", "[error]", "level=debug" or
+// "lvl=\"info\"", is used instead of the default level and it is removed from the message. The level can follow
+// the header of the std log package (date, time, prefix and source location), bracketed tags and a logfmt time:
+//
+//	writer, _ := logger.NewLogWriter(logger.GetOrCreate("std"), logger.LogInfo, true)
+//	log.SetOutput(writer)
+//	log.SetFlags(0)
+func NewLogWriter(log Logger, defaultLevel LogLevel, detectLevel bool) (*logWriter, error) {
+	if check.IfNil(log) {
+		return nil, ErrNilLogger
+	}
+
+	return &logWriter{
+		log:          log,
+		defaultLevel: defaultLevel,
+		detectLevel:  detectLevel,
+	}, nil
+}
+
+// Write outputs the complete lines of the provided bytes, keeping the unterminated line until the following writes
+// complete it (or it grows too large)
+func (lw *logWriter) Write(p []byte) (int, error) {
+	lw.mut.Lock()
+	defer lw.mut.Unlock()
+
+	buff := p
+	if len(lw.partialLine) > 0 {
+		buff = append(lw.partialLine, p...)
+		lw.partialLine = nil
+	}
+
+	for {
+		idx := bytes.IndexByte(buff, '\n')
+		if idx < 0 {
+			break
+		}
+
+		lw.outputLine(buff[:idx])
+		buff = buff[idx+1:]
+	}
+
+	if len(buff) >= maxPartialLineSize {
+		lw.outputLine(buff)
+		buff = nil
+	}
+	if len(buff) > 0 {
+		lw.partialLine = append(make([]byte, 0, len(buff)), buff...)
+	}
+
+	return len(p), nil
+}
+
+// Flush outputs the unterminated line, if any
+func (lw *logWriter) Flush() error {
+	lw.mut.Lock()
+	defer lw.mut.Unlock()
+
+	lw.outputLine(lw.partialLine)
+	lw.partialLine = nil
+
+	return nil
+}
+
+// outputLine must be called under mut
+func (lw *logWriter) outputLine(line []byte) {
+	message := strings.TrimSpace(strings.TrimSuffix(string(line), "\r"))
+	level := lw.defaultLevel
+	if lw.detectLevel {
+		message, level = detectLineLevel(message, level)
+	}
+	if len(message) == 0 {
+		return
+	}
+
+	LogAt(lw.log, level, message)
+}
+
+// detectLineLevel returns the message without the level starting it (after the line's header, if any), along with
+// that level, or else the unchanged message along with the provided default level
+func detectLineLevel(message string, defaultLevel LogLevel) (string, LogLevel) {
+	position := 0
+	for position < len(message) {
+		rest := message[position:]
+		level, length, ok := matchLineLevel(rest)
+		if ok {
+			return removeLineSection(message, position, position+length), level
+		}
+
+		header := lineHeaderRegex.FindStringIndex(rest)
+		if header == nil {
+			break
+		}
+		position += header[1]
+	}
+
+	return message, defaultLevel
+}
+
+// matchLineLevel returns the level starting the provided text and the length of its token
+func matchLineLevel(text string) (LogLevel, int, bool) {
+	match := bracketLevelRegex.FindStringSubmatchIndex(text)
+	if match != nil {
+		level, ok := parseLineLevel(text[match[2]:match[3]])
+		return level, match[1], ok
+	}
+
+	match = keyValueLevelRegex.FindStringSubmatchIndex(text)
+	if match == nil {
+		return LogTrace, 0, false
+	}
+	quoted := match[3] > match[2]
+	if quoted != (match[7] > match[6]) {
+		return LogTrace, 0, false
+	}
+
+	level, ok := parseLineLevel(text[match[4]:match[5]])
+	return level, match[1], ok
+}
+
+func parseLineLevel(name string) (LogLevel, bool) {
+	level, ok := levelAliases[strings.ToUpper(name)]
+	if ok {
+		return level, true
+	}
+
+	level, err := GetLogLevel(name)
+	if err != nil || level == LogNone {
+		return LogTrace, false
+	}
+
+	return level, true
+}
+
+func removeLineSection(message string, start int, end int) string {
+	before := strings.TrimSpace(message[:start])
+	after := strings.TrimSpace(message[end:])
+	if len(before) == 0 || len(after) == 0 {
+		return before + after
+	}
+
+	return before + " " + after
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (lw *logWriter) IsInterfaceNil() bool {
+	return lw == nil
+}
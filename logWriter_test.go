@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogWriter_NilLoggerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	lw, err := NewLogWriter(nil, LogInfo, true)
+	require.Nil(t, lw)
+	require.Equal(t, ErrNilLogger, err)
+}
+
+func TestLogWriter_ShouldSplitTheLines(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	lw, _ := NewLogWriter(NewLogger("std", LogDebug, capture), LogInfo, false)
+	require.False(t, lw.IsInterfaceNil())
+
+	n, err := lw.Write([]byte("first\r\nsec"))
+	require.Nil(t, err)
+	require.Equal(t, 10, n)
+	require.Equal(t, 1, len(capture.lines))
+
+	_, _ = lw.Write([]byte("ond\n\n  \nthi"))
+	_, _ = lw.Write([]byte("rd"))
+	require.Equal(t, 2, len(capture.lines))
+
+	require.Nil(t, lw.Flush())
+	require.Equal(t, 3, len(capture.lines))
+	require.Nil(t, lw.Flush())
+	require.Equal(t, 3, len(capture.lines))
+
+	_, _ = lw.Write([]byte(strings.Repeat("a", maxPartialLineSize)))
+	require.Equal(t, 4, len(capture.lines))
+
+	require.Equal(t, "first", capture.lines[0].Message)
+	require.Equal(t, "second", capture.lines[1].Message)
+	require.Equal(t, "third", capture.lines[2].Message)
+	for _, line := range capture.lines {
+		require.Equal(t, LogInfo, line.LogLevel)
+		require.Equal(t, "std", line.LoggerName)
+	}
+}
+
+func TestLogWriter_ShouldDetectTheLevels(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	lw, _ := NewLogWriter(NewLogger("std", LogDebug, capture), LogInfo, true)
+
+	stdLog := log.New(lw, "", 0)
+	stdLog.Printf("[WARN] disk at %d%%", 90)
+	stdLog.Print("[p2p] [error] peer lost")
+	stdLog.Print("time=now level=debug msg=connected")
+	stdLog.Print(`lvl="warning" reconnecting`)
+	stdLog.Print("[TRACE] filtered out")
+	stdLog.Print("[none] [other] no level=\"info message")
+
+	require.Equal(t, 5, len(capture.lines))
+	require.Equal(t, LogWarning, capture.lines[0].LogLevel)
+	require.Equal(t, "disk at 90%", capture.lines[0].Message)
+	require.Equal(t, LogError, capture.lines[1].LogLevel)
+	require.Equal(t, "[p2p] peer lost", capture.lines[1].Message)
+	require.Equal(t, LogDebug, capture.lines[2].LogLevel)
+	require.Equal(t, "time=now msg=connected", capture.lines[2].Message)
+	require.Equal(t, LogWarning, capture.lines[3].LogLevel)
+	require.Equal(t, "reconnecting", capture.lines[3].Message)
+	require.Equal(t, LogInfo, capture.lines[4].LogLevel)
+	require.Equal(t, "[none] [other] no level=\"info message", capture.lines[4].Message)
+}
+
+func TestLogWriter_ShouldDetectTheLevelsOnlyAtTheStartOfTheMessage(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	lw, _ := NewLogWriter(NewLogger("std", LogDebug, capture), LogInfo, true)
+
+	stdLog := log.New(lw, "app: ", log.LstdFlags|log.Lmicroseconds|log.Lshortfile)
+	stdLog.Print("[ERROR] sync failed")
+	stdLog.SetPrefix("")
+	stdLog.SetFlags(0)
+	stdLog.Print("bad field [error] in x")
+	stdLog.Print("retrying level=warn later")
+	stdLog.Print(`time="2024-01-02 10:11:12" level=warn msg=retrying`)
+
+	require.Equal(t, 4, len(capture.lines))
+	require.Equal(t, LogError, capture.lines[0].LogLevel)
+	require.True(t, strings.HasPrefix(capture.lines[0].Message, "app: "))
+	require.Contains(t, capture.lines[0].Message, "logWriter_test.go:")
+	require.True(t, strings.HasSuffix(capture.lines[0].Message, ": sync failed"))
+	require.Equal(t, LogInfo, capture.lines[1].LogLevel)
+	require.Equal(t, "bad field [error] in x", capture.lines[1].Message)
+	require.Equal(t, LogInfo, capture.lines[2].LogLevel)
+	require.Equal(t, "retrying level=warn later", capture.lines[2].Message)
+	require.Equal(t, LogWarning, capture.lines[3].LogLevel)
+	require.Equal(t, `time="2024-01-02 10:11:12" msg=retrying`, capture.lines[3].Message)
+}
@@ -2,16 +2,32 @@ package logger
 
 import (
 	"sync"
+	"sync/atomic"
 )
 
 var _ Logger = (*logger)(nil)
 
 // logger is the primary structure used to interact with the productive code
 type logger struct {
-	name      string
-	mutLevel  sync.RWMutex
-	logLevel  LogLevel
-	logOutput LogOutputHandler
+	name          string
+	mutLevel      sync.RWMutex
+	logLevel      LogLevel
+	logOutput     LogOutputHandler
+	captureCaller int32
+	captureStack  int32
+	// isRegistered is set for the loggers of GetOrCreate, whose levels are taken into account by the lowest level
+	isRegistered bool
+}
+
+// lineOptions holds the per line options of the derived loggers
+type lineOptions struct {
+	traceID    string
+	spanID     string
+	callerSkip int
+	stack      []string
+	// pc is the program counter of the line's origin, provided by the bridges knowing it (such as the slog handler),
+	// which is then used instead of the caller skip depth
+	pc uintptr
 }
 
 // NewLogger create a new logger instance
@@ -27,53 +43,99 @@ func NewLogger(name string, logLevel LogLevel, logOutput LogOutputHandler) *logg
 
 func (l *logger) shouldOutput(compareLogLevel LogLevel) bool {
 	l.mutLevel.RLock()
-	shouldOutput := l.logLevel > compareLogLevel
+	shouldOutput := l.logLevel.Priority() > compareLogLevel.Priority()
 	l.mutLevel.RUnlock()
 
 	return shouldOutput
 }
 
-func (l *logger) outputMessageFromLogLevel(level LogLevel, message string, args ...interface{}) {
+func (l *logger) outputMessage(level LogLevel, options lineOptions, message string, args ...interface{}) {
 	if l.shouldOutput(level) {
 		return
 	}
 
-	logLine := newLogLine(l.name, GetCorrelation(), message, level, args...)
+	correlation := GetCorrelation()
+	if !isAllowedByCorrelationFilter(correlation) {
+		return
+	}
+	if shouldSuppress(l.name, level, message) {
+		return
+	}
+
+	logLine := newLogLine(l.name, correlation, message, level, args...)
+	logLine.TraceID = options.traceID
+	logLine.SpanID = options.spanID
+	if l.isCallerCaptureEnabled() {
+		logLine.Caller = captureCaller(callerSkipFrames+options.callerSkip, options.pc)
+	}
+	logLine.Stack = options.stack
+	if len(logLine.Stack) == 0 && level.IsAtLeast(LogError) && l.isStackCaptureEnabled() {
+		logLine.Stack = captureStack(callerSkipFrames+options.callerSkip, options.pc)
+	}
 	l.logOutput.Output(logLine)
 }
 
 // Trace outputs a tracing log message with optional provided arguments
 func (l *logger) Trace(message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(LogTrace, message, args...)
+	l.outputMessage(LogTrace, lineOptions{}, message, args...)
 }
 
 // Debug outputs a debugging log message with optional provided arguments
 func (l *logger) Debug(message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(LogDebug, message, args...)
+	l.outputMessage(LogDebug, lineOptions{}, message, args...)
 }
 
 // Info outputs an information log message with optional provided arguments
 func (l *logger) Info(message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(LogInfo, message, args...)
+	l.outputMessage(LogInfo, lineOptions{}, message, args...)
 }
 
 // Warn outputs a warning log message with optional provided arguments
 func (l *logger) Warn(message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(LogWarning, message, args...)
+	l.outputMessage(LogWarning, lineOptions{}, message, args...)
 }
 
 // Error outputs an error log message with optional provided arguments
 func (l *logger) Error(message string, args ...interface{}) {
-	l.outputMessageFromLogLevel(LogError, message, args...)
+	l.outputMessage(LogError, lineOptions{}, message, args...)
 }
 
-// LogIfError outputs an error log message with optional provided arguments if the provided error parameter is not nil
+// Panic outputs a panic log message with optional provided arguments, flushes the observers and then panics
+// with the message
+func (l *logger) Panic(message string, args ...interface{}) {
+	l.outputMessage(LogPanic, lineOptions{}, message, args...)
+	l.flushOutput()
+	panic(message)
+}
+
+// Fatal outputs a fatal log message with optional provided arguments, flushes the observers, runs the registered
+// exit hooks and then exits the process with code 1 (see RegisterExitHook and SetExitFunction)
+func (l *logger) Fatal(message string, args ...interface{}) {
+	l.outputMessage(LogFatal, lineOptions{}, message, args...)
+	l.flushOutput()
+	exit(fatalExitCode)
+}
+
+// LogAt outputs a log message on the provided (built-in or custom) log level with optional provided arguments.
+// The Panic and Fatal levels do not panic nor exit.
+func (l *logger) LogAt(level LogLevel, message string, args ...interface{}) {
+	l.outputMessage(level, lineOptions{}, message, args...)
+}
+
+// LogIfError outputs an error log message with optional provided arguments if the provided error parameter is not nil.
+// The errors wrapped by the provided one are appended to the arguments, along with the fields of the errors
+// implementing ErrorWithFields, while the stack of the first ErrorWithStack error is output as the line's stack.
 func (l *logger) LogIfError(err error, args ...interface{}) {
 	if err == nil {
 		return
 	}
 
-	l.Error(err.Error(), args...)
+	if l.shouldOutput(LogError) {
+		return
+	}
+
+	options, args := withErrorDetails(lineOptions{}, err, args)
+	l.outputMessage(LogError, options, err.Error(), args...)
 }
 
 // Log forwards the log line towards underlying log output handler
@@ -81,6 +143,9 @@ func (l *logger) Log(line *LogLine) {
 	if line == nil {
 		return
 	}
+	if !isAllowedByCorrelationFilter(line.Correlation) {
+		return
+	}
 
 	l.logOutput.Output(line)
 }
@@ -90,6 +155,10 @@ func (l *logger) SetLevel(logLevel LogLevel) {
 	l.mutLevel.Lock()
 	l.logLevel = logLevel
 	l.mutLevel.Unlock()
+
+	if l.isRegistered {
+		lowerLowestLogLevel(logLevel)
+	}
 }
 
 // GetLevel gets the current level of the logger
@@ -100,6 +169,39 @@ func (l *logger) GetLevel() LogLevel {
 	return level
 }
 
+func (l *logger) flushOutput() {
+	flusher, ok := l.logOutput.(Flusher)
+	if ok {
+		_ = flusher.Flush()
+	}
+}
+
+func (l *logger) setCallerCapture(enable bool) {
+	value := int32(0)
+	if enable {
+		value = 1
+	}
+
+	atomic.StoreInt32(&l.captureCaller, value)
+}
+
+func (l *logger) isCallerCaptureEnabled() bool {
+	return atomic.LoadInt32(&l.captureCaller) == 1
+}
+
+func (l *logger) setStackCapture(enable bool) {
+	value := int32(0)
+	if enable {
+		value = 1
+	}
+
+	atomic.StoreInt32(&l.captureStack, value)
+}
+
+func (l *logger) isStackCaptureEnabled() bool {
+	return atomic.LoadInt32(&l.captureStack) == 1
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (l *logger) IsInterfaceNil() bool {
 	return l == nil
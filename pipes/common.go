@@ -1,3 +1,7 @@
 package pipes
 
 const sizeOfUint32 = 4
+
+// MaxMessageSize is the size of the largest message a Messenger sends or reads. The length prefix of a larger
+// message is considered garbage, so nothing gets allocated for it.
+const MaxMessageSize = 16 * 1024 * 1024
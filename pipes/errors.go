@@ -8,7 +8,14 @@ import (
 // ErrInvalidOperationGivenPartLoopState signals an error
 var ErrInvalidOperationGivenPartLoopState = errors.New("invalid operation given state of loop")
 
+// ErrMessageTooLarge signals that a message larger than MaxMessageSize has been sent or received
+var ErrMessageTooLarge = errors.New("message too large")
+
 // CreateErrUnmarshalLogLine creates an error
 func CreateErrUnmarshalLogLine(marshalized []byte, originalErr error) error {
 	return fmt.Errorf("unmarshal log line [%s]: %w", string(marshalized), originalErr)
 }
+
+func createErrMessageTooLarge(length int) error {
+	return fmt.Errorf("%w: %d bytes, the maximum is %d bytes", ErrMessageTooLarge, length, MaxMessageSize)
+}
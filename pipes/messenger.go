@@ -3,19 +3,19 @@ package pipes
 import (
 	"encoding/binary"
 	"io"
-	"os"
 	"sync"
 )
 
-// Messenger intermediates communication (message exchange) via pipes
+// Messenger intermediates communication (message exchange) via pipes or any other stream,
+// each message being prefixed by its length
 type Messenger struct {
-	reader      *os.File
-	writer      *os.File
+	reader      io.Reader
+	writer      io.Writer
 	writerMutex sync.Mutex
 }
 
 // NewMessenger creates a new messenger
-func NewMessenger(reader *os.File, writer *os.File) *Messenger {
+func NewMessenger(reader io.Reader, writer io.Writer) *Messenger {
 	return &Messenger{
 		reader: reader,
 		writer: writer,
@@ -28,6 +28,10 @@ func (messenger *Messenger) SendMessage(message []byte) (int, error) {
 	defer messenger.writerMutex.Unlock()
 
 	length := len(message)
+	if length > MaxMessageSize {
+		return 0, createErrMessageTooLarge(length)
+	}
+
 	err := messenger.sendMessageLength(length)
 	if err != nil {
 		return 0, err
@@ -48,13 +52,17 @@ func (messenger *Messenger) sendMessageLength(length int) error {
 	return err
 }
 
-// ReadMessage reads a message from the pipe
+// ReadMessage reads a message from the pipe. A message larger than MaxMessageSize is not read, as the stream can not
+// be trusted anymore.
 // Reading messages is normally performed from a single go-routine, no mutex required
 func (messenger *Messenger) ReadMessage() ([]byte, error) {
 	length, err := messenger.readMessageLength()
 	if err != nil {
 		return nil, err
 	}
+	if length > MaxMessageSize {
+		return nil, createErrMessageTooLarge(length)
+	}
 
 	return messenger.readMessagePayload(length)
 }
@@ -0,0 +1,51 @@
+package pipes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessenger_SendAndReadMessage(t *testing.T) {
+	t.Parallel()
+
+	buffer := &bytes.Buffer{}
+	messenger := NewMessenger(buffer, buffer)
+
+	length, err := messenger.SendMessage([]byte("message"))
+	require.Nil(t, err)
+	require.Equal(t, 7, length)
+
+	message, err := messenger.ReadMessage()
+	require.Nil(t, err)
+	require.Equal(t, []byte("message"), message)
+}
+
+func TestMessenger_ReadMessageTooLargeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	buffer := &bytes.Buffer{}
+	prefix := make([]byte, sizeOfUint32)
+	binary.LittleEndian.PutUint32(prefix, 0xFFFFFFFF)
+	buffer.Write(prefix)
+
+	messenger := NewMessenger(buffer, buffer)
+	message, err := messenger.ReadMessage()
+	require.Nil(t, message)
+	require.True(t, errors.Is(err, ErrMessageTooLarge))
+}
+
+func TestMessenger_SendMessageTooLargeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	buffer := &bytes.Buffer{}
+	messenger := NewMessenger(buffer, buffer)
+
+	length, err := messenger.SendMessage(make([]byte, MaxMessageSize+1))
+	require.Equal(t, 0, length)
+	require.True(t, errors.Is(err, ErrMessageTooLarge))
+	require.Equal(t, 0, buffer.Len())
+}
@@ -2,7 +2,6 @@ package pipes
 
 import (
 	"os"
-	"time"
 
 	logger "github.com/kalyan3104/dme-logger-go"
 )
@@ -34,25 +33,7 @@ func (messenger *ParentMessenger) ReadLogLine() (*logger.LogLine, error) {
 		return nil, CreateErrUnmarshalLogLine(buffer, err)
 	}
 
-	logLine := messenger.recoverLogLine(wrapper)
-	return logLine, nil
-}
-
-func (messenger *ParentMessenger) recoverLogLine(wrapper *logger.LogLineWrapper) *logger.LogLine {
-	logLine := &logger.LogLine{
-		LoggerName:  wrapper.LoggerName,
-		Correlation: wrapper.Correlation,
-		Message:     wrapper.Message,
-		LogLevel:    logger.LogLevel(wrapper.LogLevel),
-		Args:        make([]interface{}, len(wrapper.Args)),
-		Timestamp:   time.Unix(0, wrapper.Timestamp),
-	}
-
-	for i, str := range wrapper.Args {
-		logLine.Args[i] = str
-	}
-
-	return logLine
+	return logger.RecoverLogLine(wrapper), nil
 }
 
 // SendProfile sends a profile
@@ -4,7 +4,9 @@ import (
 	"os"
 	"testing"
 
+	logger "github.com/kalyan3104/dme-logger-go"
 	"github.com/kalyan3104/dme-logger-go/marshal"
+	"github.com/kalyan3104/dme-logger-go/proto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -45,3 +47,53 @@ func TestParentMessenger_ReadLogLine_BadJsonShouldErrWithActualJson(t *testing.T
 	require.Contains(t, err.Error(), "bad json")
 	require.Nil(t, logLine)
 }
+
+func TestParentMessenger_ReadLogLineShouldCarryCorrelationElements(t *testing.T) {
+	logsReader, logsWriter, err := os.Pipe()
+	require.Nil(t, err)
+	profileReader, profileWriter, err := os.Pipe()
+	require.Nil(t, err)
+
+	marshalizer := &marshal.GogoProtoMarshalizer{}
+	parentMessenger := NewParentMessenger(logsReader, profileWriter, marshalizer)
+	childMessenger := NewChildMessenger(profileReader, logsWriter)
+
+	wrapper := &logger.LogLineWrapper{}
+	wrapper.Correlation = proto.LogCorrelationMessage{
+		Shard:    "1",
+		Elements: []proto.LogCorrelationElement{{Key: "nonce", Value: "123"}, {Key: "peer", Value: "abc"}},
+	}
+	buff, err := marshalizer.Marshal(wrapper)
+	require.Nil(t, err)
+
+	childMessenger.SendLogLine(buff)
+	logLine, err := parentMessenger.ReadLogLine()
+	require.Nil(t, err)
+	require.Equal(t, wrapper.Correlation, logLine.Correlation)
+}
+
+func TestParentMessenger_ReadLogLineShouldCarryTraceAndCaller(t *testing.T) {
+	logsReader, logsWriter, err := os.Pipe()
+	require.Nil(t, err)
+	profileReader, profileWriter, err := os.Pipe()
+	require.Nil(t, err)
+
+	marshalizer := &marshal.GogoProtoMarshalizer{}
+	parentMessenger := NewParentMessenger(logsReader, profileWriter, marshalizer)
+	childMessenger := NewChildMessenger(profileReader, logsWriter)
+
+	wrapper := &logger.LogLineWrapper{}
+	wrapper.Message = "message"
+	wrapper.TraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	wrapper.SpanID = "00f067aa0ba902b7"
+	wrapper.Caller = &proto.LogCallerMessage{File: "block/shard.go", Line: 42, Function: "(*T).Method", Package: "block"}
+	buff, err := marshalizer.Marshal(wrapper)
+	require.Nil(t, err)
+
+	childMessenger.SendLogLine(buff)
+	logLine, err := parentMessenger.ReadLogLine()
+	require.Nil(t, err)
+	require.Equal(t, wrapper.TraceID, logLine.TraceID)
+	require.Equal(t, wrapper.SpanID, logLine.SpanID)
+	require.Equal(t, wrapper.Caller, logLine.Caller)
+}
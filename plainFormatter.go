@@ -28,12 +28,15 @@ func (pf *PlainFormatter) Output(line LogLineHandler) []byte {
 		correlation = formatCorrelationElements(line.GetCorrelation())
 	}
 
+	details, stack := formatDetails(line)
+	correlation = appendElements(correlation, details)
+
 	return []byte(
 		fmt.Sprintf(formatPlainString,
 			level,
 			timestamp, loggerName, correlation,
 			message, args,
-		),
+		) + stack,
 	)
 }
 
@@ -3,21 +3,43 @@ package logger
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 )
 
+var mutProfileApplyObservers = &sync.RWMutex{}
+var profileApplyObservers []ProfileApplyObserver
+
 // Profile holds global logger options
 type Profile struct {
-	LogLevelPatterns string
-	WithCorrelation  bool
-	WithLoggerName   bool
+	LogLevelPatterns          string
+	LogLevelOverrides         []LogLevelOverride `json:",omitempty"`
+	WithCorrelation           bool
+	WithLoggerName            bool
+	CorrelationFilter         string          `json:",omitempty"`
+	CallerPatterns            string          `json:",omitempty"`
+	StackTracePatterns        string          `json:",omitempty"`
+	RateLimits                []RateLimit     `json:",omitempty"`
+	SamplingRules             []SamplingRule  `json:",omitempty"`
+	SuppressionReportInterval time.Duration   `json:",omitempty"`
+	RedactionRules            []RedactionRule `json:",omitempty"`
 }
 
 // GetCurrentProfile gets the current logger profile
 func GetCurrentProfile() Profile {
 	return Profile{
-		LogLevelPatterns: GetLogLevelPattern(),
-		WithCorrelation:  IsEnabledCorrelation(),
-		WithLoggerName:   IsEnabledLoggerName(),
+		LogLevelPatterns:          GetLogLevelPattern(),
+		LogLevelOverrides:         GetLogLevelOverrides(),
+		WithCorrelation:           IsEnabledCorrelation(),
+		WithLoggerName:            IsEnabledLoggerName(),
+		CorrelationFilter:         GetCorrelationFilter(),
+		CallerPatterns:            GetCallerPatterns(),
+		StackTracePatterns:        GetStackTracePatterns(),
+		RateLimits:                GetRateLimits(),
+		SamplingRules:             GetSamplingRules(),
+		SuppressionReportInterval: GetSuppressionReportInterval(),
+		RedactionRules:            GetRedactionRules(),
 	}
 }
 
@@ -42,22 +64,108 @@ func (profile *Profile) Marshal() ([]byte, error) {
 	return data, nil
 }
 
-// Apply sets the global logger options
+// Apply sets the global logger options. The active log level overrides are replaced by the profile's ones.
+// Nothing is changed if the profile does not pass the validation (see Validate). Once applied, the profile is handed
+// to the profile apply observers, whether the profile change observers are notified or not.
 func (profile *Profile) Apply() error {
-	err := SetLogLevel(profile.LogLevelPatterns)
+	err := profile.Validate().Err()
+	if err != nil {
+		return err
+	}
+
+	err = setLogLevel(profile.LogLevelPatterns)
+	if err != nil {
+		return err
+	}
+
+	err = setLogLevelOverrides(profile.LogLevelOverrides)
+	if err != nil {
+		return err
+	}
+
+	err = setCorrelationFilter(profile.CorrelationFilter)
+	if err != nil {
+		return err
+	}
+
+	err = setCallerPatterns(profile.CallerPatterns)
+	if err != nil {
+		return err
+	}
+
+	err = setStackTracePatterns(profile.StackTracePatterns)
+	if err != nil {
+		return err
+	}
+
+	err = setLineSuppression(profile.RateLimits, profile.SamplingRules, profile.SuppressionReportInterval)
 	if err != nil {
 		return err
 	}
 
-	ToggleCorrelation(profile.WithCorrelation)
-	ToggleLoggerName(profile.WithLoggerName)
+	err = setRedactionRules(profile.RedactionRules)
+	if err != nil {
+		return err
+	}
+
+	toggleCorrelation(profile.WithCorrelation)
+	toggleLoggerName(profile.WithLoggerName)
+	notifyProfileApplied(*profile)
+	onProfileMutated(ProfileChangeSourceApply)
+
 	return nil
 }
 
+// SubscribeToProfileApply subscribes an observer called synchronously each time a profile is applied
+func SubscribeToProfileApply(observer ProfileApplyObserver) {
+	mutProfileApplyObservers.Lock()
+	profileApplyObservers = append(profileApplyObservers, observer)
+	mutProfileApplyObservers.Unlock()
+}
+
+// UnsubscribeFromProfileApply unsubscribes a profile apply observer
+func UnsubscribeFromProfileApply(observer ProfileApplyObserver) {
+	mutProfileApplyObservers.Lock()
+	defer mutProfileApplyObservers.Unlock()
+
+	for i := 0; i < len(profileApplyObservers); i++ {
+		if profileApplyObservers[i] == observer {
+			profileApplyObservers = append(profileApplyObservers[:i], profileApplyObservers[i+1:]...)
+			i--
+		}
+	}
+}
+
+func notifyProfileApplied(profile Profile) {
+	mutProfileApplyObservers.RLock()
+	observers := make([]ProfileApplyObserver, len(profileApplyObservers))
+	copy(observers, profileApplyObservers)
+	mutProfileApplyObservers.RUnlock()
+
+	for _, observer := range observers {
+		observer.OnProfileApplied(profile)
+	}
+}
+
 func (profile *Profile) String() string {
-	return fmt.Sprintf("[pattern=%s, with correlation=%t, with logger name=%t]",
+	return fmt.Sprintf("[pattern=%s, overrides=[%s], with correlation=%t, with logger name=%t, correlation filter=%s, caller patterns=%s, stack trace patterns=%s, line suppression=[%s], redaction=[%s]]",
 		profile.LogLevelPatterns,
+		formatLogLevelOverrides(profile.LogLevelOverrides),
 		profile.WithCorrelation,
 		profile.WithLoggerName,
+		profile.CorrelationFilter,
+		profile.CallerPatterns,
+		profile.StackTracePatterns,
+		formatLineSuppression(profile.RateLimits, profile.SamplingRules),
+		formatRedactionRules(profile.RedactionRules),
 	)
 }
+
+func formatLogLevelOverrides(overrides []LogLevelOverride) string {
+	formatted := make([]string, len(overrides))
+	for i, override := range overrides {
+		formatted[i] = fmt.Sprintf("%s for %s", override.Pattern, override.Remaining.Round(time.Second))
+	}
+
+	return strings.Join(formatted, ", ")
+}
@@ -2,64 +2,306 @@ package logger
 
 import (
 	"sync"
+	"time"
 )
 
+// The sources of a profile change, as reported by ProfileChangeEvent
+const (
+	ProfileChangeSourceManual                       = "NotifyProfileChange"
+	ProfileChangeSourceSetLogLevel                  = "SetLogLevel"
+	ProfileChangeSourceToggleCorrelation            = "ToggleCorrelation"
+	ProfileChangeSourceToggleLoggerName             = "ToggleLoggerName"
+	ProfileChangeSourceApply                        = "Profile.Apply"
+	ProfileChangeSourceSetLogLevelFor               = "SetLogLevelFor"
+	ProfileChangeSourceOverrideExpired              = "LogLevelOverrideExpired"
+	ProfileChangeSourceClearLevelOverrides          = "ClearLogLevelOverrides"
+	ProfileChangeSourceSetCorrelationFilter         = "SetCorrelationFilter"
+	ProfileChangeSourceSetCallerPatterns            = "SetCallerPatterns"
+	ProfileChangeSourceSetStackTracePatterns        = "SetStackTracePatterns"
+	ProfileChangeSourceSetRateLimits                = "SetRateLimits"
+	ProfileChangeSourceSetSamplingRules             = "SetSamplingRules"
+	ProfileChangeSourceSetSuppressionReportInterval = "SetSuppressionReportInterval"
+	ProfileChangeSourceSetRedactionRules            = "SetRedactionRules"
+)
+
+// ProfileChangeEvent describes a profile change: the profile at the time of the previous notification,
+// the current profile and the source of the (most recent) change
+type ProfileChangeEvent struct {
+	Previous Profile
+	Current  Profile
+	Source   string
+}
+
 var globalProfileChangeSubject *profileChangeSubject
 
 func init() {
 	globalProfileChangeSubject = NewProfileChangeSubject()
 }
 
-// SubscribeToProfileChange subscribes an observer
+// SubscribeToProfileChange subscribes an observer, called synchronously by the notifying goroutine
 func SubscribeToProfileChange(observer ProfileChangeObserver) {
-	globalProfileChangeSubject.subscribe(observer)
+	globalProfileChangeSubject.subscribeObserver(observer)
 }
 
 // UnsubscribeFromProfileChange unsubscribes an observer
 func UnsubscribeFromProfileChange(observer ProfileChangeObserver) {
-	globalProfileChangeSubject.unsubscribe(observer)
+	globalProfileChangeSubject.unsubscribeObserver(observer)
+}
+
+// SubscribeToProfileChangeEvents subscribes an observer interested in the details of the profile changes. The observer
+// is called on its own goroutine, in the order of the events. When it falls behind by maxPendingProfileChangeEvents
+// events, the newer events are merged into the last pending one, which then spans all of them.
+func SubscribeToProfileChangeEvents(observer ProfileChangeEventObserver) {
+	globalProfileChangeSubject.subscribeEventsObserver(observer)
+}
+
+// UnsubscribeFromProfileChangeEvents unsubscribes a profile change events observer
+func UnsubscribeFromProfileChangeEvents(observer ProfileChangeEventObserver) {
+	globalProfileChangeSubject.unsubscribeEventsObserver(observer)
 }
 
 // NotifyProfileChange notifies observers about a profile change
 func NotifyProfileChange() {
-	globalProfileChangeSubject.NotifyAll()
+	globalProfileChangeSubject.notifyAll(ProfileChangeSourceManual)
+}
+
+// SetAutoNotifyProfileChange enables or disables the automatic notification of the observers on each mutating call
+// (SetLogLevel, ToggleCorrelation, ToggleLoggerName, Profile.Apply). When a positive debounce interval is provided,
+// the changes made within the interval are notified as a single event.
+func SetAutoNotifyProfileChange(enable bool, debounce time.Duration) {
+	globalProfileChangeSubject.setAutoNotify(enable, debounce)
+}
+
+func notifyProfileChange(source string) {
+	globalProfileChangeSubject.notifyAll(source)
+}
+
+func onProfileMutated(source string) {
+	globalProfileChangeSubject.onMutation(source)
 }
 
 type profileChangeSubject struct {
-	observers []ProfileChangeObserver
-	mutex     sync.RWMutex
+	observers       []*profileChangeDelivery
+	eventsObservers []*profileChangeDelivery
+	mutex           sync.RWMutex
+
+	mutNotify   sync.Mutex
+	lastProfile Profile
+
+	mutAutoNotify  sync.Mutex
+	autoNotify     bool
+	debounce       time.Duration
+	debounceTimer  *time.Timer
+	debounceSource string
 }
 
 // NewProfileChangeSubject -
 func NewProfileChangeSubject() *profileChangeSubject {
 	return &profileChangeSubject{
-		observers: make([]ProfileChangeObserver, 0),
+		observers:       make([]*profileChangeDelivery, 0),
+		eventsObservers: make([]*profileChangeDelivery, 0),
+		lastProfile:     GetCurrentProfile(),
 	}
 }
 
-func (subject *profileChangeSubject) subscribe(observer ProfileChangeObserver) {
+func (subject *profileChangeSubject) subscribeObserver(observer ProfileChangeObserver) {
+	delivery := newProfileChangeDelivery(observer, true, func(_ ProfileChangeEvent) {
+		observer.OnProfileChanged()
+	})
+
+	subject.mutex.Lock()
+	subject.observers = append(subject.observers, delivery)
+	subject.mutex.Unlock()
+}
+
+func (subject *profileChangeSubject) subscribeEventsObserver(observer ProfileChangeEventObserver) {
+	delivery := newProfileChangeDelivery(observer, false, observer.OnProfileChangeEvent)
+
+	subject.mutex.Lock()
+	subject.eventsObservers = append(subject.eventsObservers, delivery)
+	subject.mutex.Unlock()
+}
+
+// unsubscribeObserver removes the observer from the synchronous observers only, so that the same value subscribed
+// to the change events keeps receiving them
+func (subject *profileChangeSubject) unsubscribeObserver(observer ProfileChangeObserver) {
 	subject.mutex.Lock()
-	subject.observers = append(subject.observers, observer)
+	subject.observers = removeProfileChangeDeliveries(subject.observers, observer)
 	subject.mutex.Unlock()
 }
 
-func (subject *profileChangeSubject) unsubscribe(observer ProfileChangeObserver) {
+// unsubscribeEventsObserver removes the observer from the change events observers only
+func (subject *profileChangeSubject) unsubscribeEventsObserver(observer ProfileChangeEventObserver) {
 	subject.mutex.Lock()
-	defer subject.mutex.Unlock()
+	subject.eventsObservers = removeProfileChangeDeliveries(subject.eventsObservers, observer)
+	subject.mutex.Unlock()
+}
 
-	for i := 0; i < len(subject.observers); i++ {
-		if subject.observers[i] == observer {
-			subject.observers = append(subject.observers[0:i], subject.observers[i+1:]...)
+func removeProfileChangeDeliveries(deliveries []*profileChangeDelivery, observer interface{}) []*profileChangeDelivery {
+	for i := 0; i < len(deliveries); i++ {
+		if deliveries[i].observer == observer {
+			deliveries[i].close()
+			deliveries = append(deliveries[0:i], deliveries[i+1:]...)
+			i--
 		}
 	}
+
+	return deliveries
 }
 
-// Notifies all observers that the profile changed
+// NotifyAll notifies all observers that the profile changed
 func (subject *profileChangeSubject) NotifyAll() {
+	subject.notifyAll(ProfileChangeSourceManual)
+}
+
+// notifyAll hands the change event to each events observer's delivery queue, so that a slow observer does not block
+// the notifier (or the other observers), and then calls the synchronous observers
+func (subject *profileChangeSubject) notifyAll(source string) {
 	subject.mutex.RLock()
-	defer subject.mutex.RUnlock()
+	deliveries := make([]*profileChangeDelivery, len(subject.observers))
+	copy(deliveries, subject.observers)
+	eventsDeliveries := make([]*profileChangeDelivery, len(subject.eventsObservers))
+	copy(eventsDeliveries, subject.eventsObservers)
+	subject.mutex.RUnlock()
 
-	for _, observer := range subject.observers {
-		observer.OnProfileChanged()
+	subject.mutNotify.Lock()
+	current := GetCurrentProfile()
+	event := ProfileChangeEvent{
+		Previous: subject.lastProfile,
+		Current:  current,
+		Source:   source,
+	}
+	subject.lastProfile = current
+
+	for _, delivery := range eventsDeliveries {
+		delivery.enqueue(event)
+	}
+	subject.mutNotify.Unlock()
+
+	for _, delivery := range deliveries {
+		delivery.handler(event)
+	}
+}
+
+func (subject *profileChangeSubject) setAutoNotify(enable bool, debounce time.Duration) {
+	subject.mutAutoNotify.Lock()
+	subject.autoNotify = enable
+	subject.debounce = debounce
+	subject.mutAutoNotify.Unlock()
+}
+
+func (subject *profileChangeSubject) onMutation(source string) {
+	subject.mutAutoNotify.Lock()
+	if !subject.autoNotify {
+		subject.mutAutoNotify.Unlock()
+		return
+	}
+	if subject.debounce <= 0 {
+		subject.mutAutoNotify.Unlock()
+		subject.notifyAll(source)
+		return
+	}
+
+	subject.debounceSource = source
+	if subject.debounceTimer == nil {
+		subject.debounceTimer = time.AfterFunc(subject.debounce, subject.onDebounceElapsed)
+	}
+	subject.mutAutoNotify.Unlock()
+}
+
+func (subject *profileChangeSubject) onDebounceElapsed() {
+	subject.mutAutoNotify.Lock()
+	source := subject.debounceSource
+	subject.debounceTimer = nil
+	subject.mutAutoNotify.Unlock()
+
+	subject.notifyAll(source)
+}
+
+// maxPendingProfileChangeEvents is the number of events an observer can fall behind before they are merged
+const maxPendingProfileChangeEvents = 64
+
+// profileChangeDelivery calls a synchronous observer directly or, otherwise, holds the pending events of the observer
+// and calls it on a dedicated goroutine
+type profileChangeDelivery struct {
+	observer    interface{}
+	handler     func(event ProfileChangeEvent)
+	synchronous bool
+
+	mutPending sync.Mutex
+	pending    []ProfileChangeEvent
+	chSignal   chan struct{}
+	chClose    chan struct{}
+}
+
+func newProfileChangeDelivery(observer interface{}, synchronous bool, handler func(event ProfileChangeEvent)) *profileChangeDelivery {
+	delivery := &profileChangeDelivery{
+		observer:    observer,
+		handler:     handler,
+		synchronous: synchronous,
+		chSignal:    make(chan struct{}, 1),
+		chClose:     make(chan struct{}),
+	}
+
+	if !synchronous {
+		go delivery.continuouslyDeliver()
 	}
+
+	return delivery
+}
+
+// enqueue appends the event to the pending ones or, when there are too many, merges it into the last pending one:
+// the merged event keeps the previous profile of the last pending event and takes the current profile and source
+// of the new one
+func (delivery *profileChangeDelivery) enqueue(event ProfileChangeEvent) {
+	delivery.mutPending.Lock()
+	numPending := len(delivery.pending)
+	if numPending < maxPendingProfileChangeEvents {
+		delivery.pending = append(delivery.pending, event)
+	} else {
+		delivery.pending[numPending-1].Current = event.Current
+		delivery.pending[numPending-1].Source = event.Source
+	}
+	delivery.mutPending.Unlock()
+
+	select {
+	case delivery.chSignal <- struct{}{}:
+	default:
+	}
+}
+
+func (delivery *profileChangeDelivery) continuouslyDeliver() {
+	for {
+		select {
+		case <-delivery.chClose:
+			return
+		case <-delivery.chSignal:
+			delivery.deliverPending()
+		}
+	}
+}
+
+func (delivery *profileChangeDelivery) deliverPending() {
+	for {
+		delivery.mutPending.Lock()
+		if len(delivery.pending) == 0 {
+			delivery.mutPending.Unlock()
+			return
+		}
+		event := delivery.pending[0]
+		delivery.pending = delivery.pending[1:]
+		delivery.mutPending.Unlock()
+
+		select {
+		case <-delivery.chClose:
+			return
+		default:
+		}
+
+		delivery.handler(event)
+	}
+}
+
+func (delivery *profileChangeDelivery) close() {
+	close(delivery.chClose)
 }
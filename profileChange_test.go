@@ -0,0 +1,220 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type profileChangeEventsRecorder struct {
+	mut    sync.Mutex
+	events []ProfileChangeEvent
+}
+
+func (recorder *profileChangeEventsRecorder) OnProfileChangeEvent(event ProfileChangeEvent) {
+	recorder.mut.Lock()
+	recorder.events = append(recorder.events, event)
+	recorder.mut.Unlock()
+}
+
+func (recorder *profileChangeEventsRecorder) getEvents() []ProfileChangeEvent {
+	recorder.mut.Lock()
+	defer recorder.mut.Unlock()
+
+	return append([]ProfileChangeEvent(nil), recorder.events...)
+}
+
+func (recorder *profileChangeEventsRecorder) waitForEvents(t *testing.T, numEvents int) []ProfileChangeEvent {
+	require.Eventually(t, func() bool {
+		return len(recorder.getEvents()) >= numEvents
+	}, time.Second, 5*time.Millisecond)
+
+	return recorder.getEvents()
+}
+
+type blockingProfileChangeEventObserver struct {
+	profileChangeEventsRecorder
+	chUnblock chan struct{}
+}
+
+func (observer *blockingProfileChangeEventObserver) OnProfileChangeEvent(event ProfileChangeEvent) {
+	<-observer.chUnblock
+	observer.profileChangeEventsRecorder.OnProfileChangeEvent(event)
+}
+
+func TestNotifyProfileChange_ShouldCarryPreviousAndCurrentProfile(t *testing.T) {
+	defer resetLogLevels()
+
+	_ = SetLogLevel("*:INFO")
+	NotifyProfileChange()
+
+	recorder := &profileChangeEventsRecorder{}
+	SubscribeToProfileChangeEvents(recorder)
+	defer UnsubscribeFromProfileChangeEvents(recorder)
+
+	_ = SetLogLevel("*:DEBUG")
+	NotifyProfileChange()
+
+	events := recorder.waitForEvents(t, 1)
+	require.Equal(t, "*:INFO", events[0].Previous.LogLevelPatterns)
+	require.Equal(t, "*:DEBUG", events[0].Current.LogLevelPatterns)
+	require.Equal(t, ProfileChangeSourceManual, events[0].Source)
+}
+
+func TestSetAutoNotifyProfileChange_ShouldNotifyOnEachMutation(t *testing.T) {
+	defer resetLogLevels()
+	defer SetAutoNotifyProfileChange(false, 0)
+
+	recorder := &profileChangeEventsRecorder{}
+	SubscribeToProfileChangeEvents(recorder)
+	defer UnsubscribeFromProfileChangeEvents(recorder)
+
+	SetAutoNotifyProfileChange(true, 0)
+	_ = SetLogLevel("*:TRACE")
+	ToggleLoggerName(true)
+	profile := Profile{LogLevelPatterns: "*:WARN"}
+	_ = profile.Apply()
+
+	events := recorder.waitForEvents(t, 3)
+	require.Equal(t, 3, len(events))
+	require.Equal(t, ProfileChangeSourceSetLogLevel, events[0].Source)
+	require.Equal(t, "*:TRACE", events[0].Current.LogLevelPatterns)
+	require.Equal(t, ProfileChangeSourceToggleLoggerName, events[1].Source)
+	require.True(t, events[1].Current.WithLoggerName)
+	require.Equal(t, ProfileChangeSourceApply, events[2].Source)
+	require.Equal(t, events[1].Current, events[2].Previous)
+	require.Equal(t, "*:WARN", events[2].Current.LogLevelPatterns)
+}
+
+func TestSetAutoNotifyProfileChange_WithDebounceShouldCoalesce(t *testing.T) {
+	defer resetLogLevels()
+	defer SetAutoNotifyProfileChange(false, 0)
+
+	_ = SetLogLevel("*:INFO")
+	NotifyProfileChange()
+
+	recorder := &profileChangeEventsRecorder{}
+	SubscribeToProfileChangeEvents(recorder)
+	defer UnsubscribeFromProfileChangeEvents(recorder)
+
+	SetAutoNotifyProfileChange(true, 50*time.Millisecond)
+	_ = SetLogLevel("*:DEBUG")
+	_ = SetLogLevel("*:TRACE")
+	ToggleCorrelation(true)
+	ToggleCorrelation(false)
+
+	recorder.waitForEvents(t, 1)
+	time.Sleep(100 * time.Millisecond)
+	events := recorder.getEvents()
+	require.Equal(t, 1, len(events))
+	require.Equal(t, "*:INFO", events[0].Previous.LogLevelPatterns)
+	require.Equal(t, "*:TRACE", events[0].Current.LogLevelPatterns)
+	require.Equal(t, ProfileChangeSourceToggleCorrelation, events[0].Source)
+}
+
+func TestNotifyProfileChange_SlowObserverShouldNotBlock(t *testing.T) {
+	slowObserver := &blockingProfileChangeEventObserver{chUnblock: make(chan struct{})}
+	SubscribeToProfileChangeEvents(slowObserver)
+	defer func() {
+		close(slowObserver.chUnblock)
+		UnsubscribeFromProfileChangeEvents(slowObserver)
+	}()
+
+	recorder := &profileChangeEventsRecorder{}
+	SubscribeToProfileChangeEvents(recorder)
+	defer UnsubscribeFromProfileChangeEvents(recorder)
+
+	chDone := make(chan struct{})
+	go func() {
+		NotifyProfileChange()
+		NotifyProfileChange()
+		close(chDone)
+	}()
+
+	select {
+	case <-chDone:
+	case <-time.After(time.Second):
+		require.Fail(t, "NotifyProfileChange has been blocked by a slow observer")
+	}
+
+	recorder.waitForEvents(t, 2)
+}
+
+func TestUnsubscribeFromProfileChangeEvents_ShouldStopDelivery(t *testing.T) {
+	recorder := &profileChangeEventsRecorder{}
+	SubscribeToProfileChangeEvents(recorder)
+	UnsubscribeFromProfileChangeEvents(recorder)
+
+	NotifyProfileChange()
+	time.Sleep(20 * time.Millisecond)
+
+	require.Empty(t, recorder.getEvents())
+}
+
+func TestNotifyProfileChange_SlowObserverShouldGetTheMergedEvents(t *testing.T) {
+	defer resetLogLevels()
+
+	_ = SetLogLevel("*:INFO")
+	NotifyProfileChange()
+
+	slowObserver := &blockingProfileChangeEventObserver{chUnblock: make(chan struct{})}
+	SubscribeToProfileChangeEvents(slowObserver)
+	defer UnsubscribeFromProfileChangeEvents(slowObserver)
+
+	numNotifications := 2 * maxPendingProfileChangeEvents
+	for i := 0; i < numNotifications-1; i++ {
+		NotifyProfileChange()
+	}
+	_ = SetLogLevel("*:DEBUG")
+	notifyProfileChange(ProfileChangeSourceSetLogLevel)
+	close(slowObserver.chUnblock)
+
+	// the first event might have been taken off the queue before the observer blocked
+	slowObserver.waitForEvents(t, maxPendingProfileChangeEvents)
+	time.Sleep(20 * time.Millisecond)
+	events := slowObserver.getEvents()
+	require.LessOrEqual(t, len(events), maxPendingProfileChangeEvents+1)
+	require.Equal(t, "*:INFO", events[0].Previous.LogLevelPatterns)
+	lastEvent := events[len(events)-1]
+	require.Equal(t, "*:INFO", lastEvent.Previous.LogLevelPatterns)
+	require.Equal(t, "*:DEBUG", lastEvent.Current.LogLevelPatterns)
+	require.Equal(t, ProfileChangeSourceSetLogLevel, lastEvent.Source)
+}
+
+func TestNotifyProfileChange_ShouldCallTheObserversSynchronously(t *testing.T) {
+	counter := &profileChangeCounter{}
+	SubscribeToProfileChange(counter)
+	defer UnsubscribeFromProfileChange(counter)
+
+	NotifyProfileChange()
+	require.Equal(t, int32(1), counter.get())
+	NotifyProfileChange()
+	require.Equal(t, int32(2), counter.get())
+}
+
+type profileChangeBothObserver struct {
+	profileChangeCounter
+	profileChangeEventsRecorder
+}
+
+func TestUnsubscribe_ShouldOnlyRemoveTheSubscriptionOfItsKind(t *testing.T) {
+	observer := &profileChangeBothObserver{}
+	SubscribeToProfileChange(observer)
+	SubscribeToProfileChangeEvents(observer)
+	defer UnsubscribeFromProfileChange(observer)
+	defer UnsubscribeFromProfileChangeEvents(observer)
+
+	UnsubscribeFromProfileChange(observer)
+	NotifyProfileChange()
+	observer.waitForEvents(t, 1)
+	require.Equal(t, int32(0), observer.get())
+
+	SubscribeToProfileChange(observer)
+	UnsubscribeFromProfileChangeEvents(observer)
+	NotifyProfileChange()
+	require.Equal(t, int32(1), observer.get())
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, 1, len(observer.getEvents()))
+}
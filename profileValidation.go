@@ -0,0 +1,365 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The severities of the profile diagnostics
+const (
+	DiagnosticError   = "error"
+	DiagnosticWarning = "warning"
+)
+
+// The profile fields checked by Profile.Validate
+const (
+	fieldLogLevelPatterns          = "LogLevelPatterns"
+	fieldLogLevelOverrides         = "LogLevelOverrides"
+	fieldCorrelationFilter         = "CorrelationFilter"
+	fieldCallerPatterns            = "CallerPatterns"
+	fieldStackTracePatterns        = "StackTracePatterns"
+	fieldRateLimits                = "RateLimits"
+	fieldSamplingRules             = "SamplingRules"
+	fieldSuppressionReportInterval = "SuppressionReportInterval"
+	fieldRedactionRules            = "RedactionRules"
+)
+
+const maxSuggestionDistance = 2
+
+// ProfileDiagnostic describes an issue found while validating a profile: the field and the index of the rule
+// (a MATCHING_STRING:LOG_LEVEL pair) where it was found, the offending token and the suggested replacements, if any
+type ProfileDiagnostic struct {
+	Severity    string
+	Field       string
+	RuleIndex   int
+	Token       string
+	Message     string
+	Suggestions []string
+}
+
+// String returns a human readable form of the diagnostic
+func (diagnostic ProfileDiagnostic) String() string {
+	str := fmt.Sprintf("%s: %s rule %d", diagnostic.Severity, diagnostic.Field, diagnostic.RuleIndex)
+	if len(diagnostic.Token) > 0 {
+		str += fmt.Sprintf(" '%s'", diagnostic.Token)
+	}
+	str += ": " + diagnostic.Message
+	if len(diagnostic.Suggestions) > 0 {
+		str += fmt.Sprintf(", did you mean %s?", strings.Join(diagnostic.Suggestions, " or "))
+	}
+
+	return str
+}
+
+// ProfileDiagnostics is the list of diagnostics returned by Profile.Validate
+type ProfileDiagnostics []ProfileDiagnostic
+
+// HasErrors returns true if any of the diagnostics is an error (the warnings do not prevent applying the profile)
+func (diagnostics ProfileDiagnostics) HasErrors() bool {
+	return diagnostics.Err() != nil
+}
+
+// Err returns an error describing the first error diagnostic, or nil if there are only warnings
+func (diagnostics ProfileDiagnostics) Err() error {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == DiagnosticError {
+			return fmt.Errorf("%w: %s", ErrInvalidProfile, diagnostic.String())
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the profile's log level patterns and overrides, returning a diagnostic for each unparsable rule
+// (with the closest log level names as suggestions for the unknown log levels) and warnings for the rules
+// shadowed by a later "*" rule and for the matching strings that match none of the currently registered loggers
+func (profile *Profile) Validate() ProfileDiagnostics {
+	logMut.RLock()
+	loggerNames := make([]string, 0, len(loggers))
+	for name := range loggers {
+		loggerNames = append(loggerNames, name)
+	}
+	logMut.RUnlock()
+
+	diagnostics := validateLogLevelPatterns(fieldLogLevelPatterns, profile.LogLevelPatterns, loggerNames)
+	for i, override := range profile.LogLevelOverrides {
+		field := fmt.Sprintf("%s[%d]", fieldLogLevelOverrides, i)
+		diagnostics = append(diagnostics, validateLogLevelPatterns(field, override.Pattern, loggerNames)...)
+		if override.Remaining <= 0 {
+			diagnostics = append(diagnostics, ProfileDiagnostic{
+				Severity: DiagnosticWarning,
+				Field:    field,
+				Token:    override.Remaining.String(),
+				Message:  "expired override, it will be ignored",
+			})
+		}
+	}
+
+	if len(strings.TrimSpace(profile.CorrelationFilter)) > 0 {
+		_, err := NewCorrelationFilter(profile.CorrelationFilter)
+		if err != nil {
+			diagnostics = append(diagnostics, ProfileDiagnostic{
+				Severity: DiagnosticError,
+				Field:    fieldCorrelationFilter,
+				Token:    profile.CorrelationFilter,
+				Message:  err.Error(),
+			})
+		}
+	}
+
+	diagnostics = append(diagnostics, validateMatchingStrings(fieldCallerPatterns, profile.CallerPatterns, ParseCallerPatterns, loggerNames)...)
+	diagnostics = append(diagnostics, validateMatchingStrings(fieldStackTracePatterns, profile.StackTracePatterns, ParseStackTracePatterns, loggerNames)...)
+
+	diagnostics = append(diagnostics, validateLineSuppressionRules(profile)...)
+
+	err := validateRedactionRules(profile.RedactionRules)
+	if err != nil {
+		diagnostics = append(diagnostics, ProfileDiagnostic{
+			Severity: DiagnosticError,
+			Field:    fieldRedactionRules,
+			Message:  err.Error(),
+		})
+	}
+
+	return diagnostics
+}
+
+func validateLineSuppressionRules(profile *Profile) ProfileDiagnostics {
+	diagnostics := make(ProfileDiagnostics, 0)
+	for i, rateLimit := range profile.RateLimits {
+		err := validateRateLimit(i, rateLimit)
+		if err != nil {
+			diagnostics = append(diagnostics, ProfileDiagnostic{
+				Severity:  DiagnosticError,
+				Field:     fieldRateLimits,
+				RuleIndex: i,
+				Token:     rateLimit.Pattern,
+				Message:   err.Error(),
+			})
+		}
+	}
+
+	for i, rule := range profile.SamplingRules {
+		err := validateSamplingRule(i, rule)
+		if err != nil {
+			diagnostics = append(diagnostics, ProfileDiagnostic{
+				Severity:  DiagnosticError,
+				Field:     fieldSamplingRules,
+				RuleIndex: i,
+				Token:     rule.Pattern,
+				Message:   err.Error(),
+			})
+		}
+	}
+
+	err := validateSuppressionReportInterval(profile.SuppressionReportInterval)
+	if err != nil {
+		diagnostics = append(diagnostics, ProfileDiagnostic{
+			Severity: DiagnosticError,
+			Field:    fieldSuppressionReportInterval,
+			Token:    profile.SuppressionReportInterval.String(),
+			Message:  err.Error(),
+		})
+	}
+
+	return diagnostics
+}
+
+func validateMatchingStrings(
+	field string,
+	patterns string,
+	parse func(patterns string) ([]string, error),
+	loggerNames []string,
+) ProfileDiagnostics {
+	matchingStrings, err := parse(patterns)
+	if err != nil {
+		return ProfileDiagnostics{{
+			Severity: DiagnosticError,
+			Field:    field,
+			Token:    patterns,
+			Message:  err.Error(),
+		}}
+	}
+
+	diagnostics := make(ProfileDiagnostics, 0)
+	for i, matchingString := range matchingStrings {
+		if matchingString != "*" && !isMatchingAnyLogger(matchingString, loggerNames) {
+			diagnostics = append(diagnostics, ProfileDiagnostic{
+				Severity:  DiagnosticWarning,
+				Field:     field,
+				RuleIndex: i,
+				Token:     matchingString,
+				Message:   "matching string does not match any registered logger",
+			})
+		}
+	}
+
+	return diagnostics
+}
+
+func validateLogLevelPatterns(field string, logLevelAndPatterns string, loggerNames []string) ProfileDiagnostics {
+	if len(strings.TrimSpace(logLevelAndPatterns)) == 0 {
+		return ProfileDiagnostics{{
+			Severity: DiagnosticError,
+			Field:    field,
+			Message:  "empty log level patterns, expected MATCHING_STRING:LOG_LEVEL pairs separated by commas",
+		}}
+	}
+
+	diagnostics := make(ProfileDiagnostics, 0)
+	rules := strings.Split(logLevelAndPatterns, ",")
+	patterns := make([]string, len(rules))
+	for i, rule := range rules {
+		diagnostic, ok := validateRule(rule)
+		if !ok {
+			diagnostic.Field = field
+			diagnostic.RuleIndex = i
+			diagnostics = append(diagnostics, diagnostic)
+			continue
+		}
+
+		patterns[i] = strings.Split(rule, ":")[0]
+		if len(patterns[i]) == 0 {
+			diagnostics = append(diagnostics, ProfileDiagnostic{
+				Severity:  DiagnosticWarning,
+				Field:     field,
+				RuleIndex: i,
+				Token:     rule,
+				Message:   "empty matching string matches all the existing loggers, use * to also set the default log level",
+			})
+		}
+	}
+
+	for i, pattern := range patterns {
+		if len(pattern) == 0 {
+			continue
+		}
+
+		shadowingIndex := findShadowingRule(patterns, i)
+		if shadowingIndex >= 0 {
+			diagnostics = append(diagnostics, ProfileDiagnostic{
+				Severity:  DiagnosticWarning,
+				Field:     field,
+				RuleIndex: i,
+				Token:     rules[i],
+				Message:   fmt.Sprintf("rule shadowed by the later rule %d '%s'", shadowingIndex, rules[shadowingIndex]),
+			})
+			continue
+		}
+
+		if pattern != "*" && !isMatchingAnyLogger(pattern, loggerNames) {
+			diagnostics = append(diagnostics, ProfileDiagnostic{
+				Severity:  DiagnosticWarning,
+				Field:     field,
+				RuleIndex: i,
+				Token:     pattern,
+				Message:   "matching string does not match any registered logger",
+			})
+		}
+	}
+
+	return diagnostics
+}
+
+// validateRule returns false and the corresponding diagnostic if the rule can not be parsed
+func validateRule(rule string) (ProfileDiagnostic, bool) {
+	if len(strings.TrimSpace(rule)) == 0 {
+		return ProfileDiagnostic{
+			Severity: DiagnosticError,
+			Message:  "empty rule, expected MATCHING_STRING:LOG_LEVEL",
+		}, false
+	}
+
+	input := strings.Split(rule, ":")
+	if len(input) != 2 {
+		return ProfileDiagnostic{
+			Severity: DiagnosticError,
+			Token:    rule,
+			Message:  ErrInvalidLogLevelPattern.Error() + ", expected MATCHING_STRING:LOG_LEVEL",
+		}, false
+	}
+	_, err := GetLogLevel(input[1])
+	if err != nil {
+		return ProfileDiagnostic{
+			Severity:    DiagnosticError,
+			Token:       input[1],
+			Message:     err.Error(),
+			Suggestions: suggestLogLevels(input[1]),
+		}, false
+	}
+
+	return ProfileDiagnostic{}, true
+}
+
+// findShadowingRule returns the index of the last "*" rule following the provided one, -1 if there is none
+func findShadowingRule(patterns []string, index int) int {
+	for i := len(patterns) - 1; i > index; i-- {
+		if patterns[i] == "*" {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isMatchingAnyLogger(pattern string, loggerNames []string) bool {
+	for _, name := range loggerNames {
+		if isMatchingPattern(name, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// suggestLogLevels returns the log level names closest to the provided one
+func suggestLogLevels(logLevel string) []string {
+	logLevel = strings.ToUpper(strings.TrimSpace(logLevel))
+
+	suggestions := make([]string, 0)
+	minDistance := maxSuggestionDistance + 1
+	for _, level := range RegisteredLogLevels() {
+		name := strings.TrimSpace(level.String())
+		distance := levenshteinDistance(logLevel, name)
+		if distance > minDistance {
+			continue
+		}
+		if distance < minDistance {
+			minDistance = distance
+			suggestions = suggestions[:0]
+		}
+		suggestions = append(suggestions, name)
+	}
+
+	return suggestions
+}
+
+func levenshteinDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
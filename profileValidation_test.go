@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile_ValidateValidProfileShouldNotReturnDiagnostics(t *testing.T) {
+	_ = GetOrCreate("validate/process")
+
+	profile := Profile{
+		LogLevelPatterns:  "*:INFO,validate/process:DEBUG",
+		LogLevelOverrides: []LogLevelOverride{{Pattern: "process:trace", Remaining: time.Minute}},
+	}
+	diagnostics := profile.Validate()
+	require.Empty(t, diagnostics)
+	require.Nil(t, diagnostics.Err())
+}
+
+func TestProfile_ValidateShouldReportErrors(t *testing.T) {
+	profile := Profile{LogLevelPatterns: ""}
+	diagnostics := profile.Validate()
+	require.Equal(t, 1, len(diagnostics))
+	require.Equal(t, DiagnosticError, diagnostics[0].Severity)
+	require.Equal(t, "LogLevelPatterns", diagnostics[0].Field)
+
+	profile = Profile{
+		LogLevelPatterns:  "*:INFO,p2p:DEBG,,process",
+		LogLevelOverrides: []LogLevelOverride{{Pattern: "*:WARNING", Remaining: time.Minute}},
+	}
+	diagnostics = profile.Validate()
+	require.True(t, diagnostics.HasErrors())
+	require.Equal(t, 4, len(diagnostics))
+
+	require.Equal(t, 1, diagnostics[0].RuleIndex)
+	require.Equal(t, "DEBG", diagnostics[0].Token)
+	require.Equal(t, []string{"DEBUG"}, diagnostics[0].Suggestions)
+	require.Equal(t,
+		"error: LogLevelPatterns rule 1 'DEBG': unknown log level provided 'DEBG', did you mean DEBUG?",
+		diagnostics[0].String())
+
+	require.Equal(t, 2, diagnostics[1].RuleIndex)
+	require.Equal(t, 3, diagnostics[2].RuleIndex)
+	require.Equal(t, "process", diagnostics[2].Token)
+
+	require.Equal(t, "LogLevelOverrides[0]", diagnostics[3].Field)
+	require.Equal(t, []string{"WARN"}, diagnostics[3].Suggestions)
+
+	err := diagnostics.Err()
+	require.True(t, errors.Is(err, ErrInvalidProfile))
+	require.Contains(t, err.Error(), "rule 1 'DEBG'")
+}
+
+func TestProfile_ValidateShouldReportWarnings(t *testing.T) {
+	_ = GetOrCreate("validate/sync")
+
+	profile := Profile{
+		LogLevelPatterns:  "validate/sync:TRACE,*:INFO,nonexistent:DEBUG,:ERROR",
+		LogLevelOverrides: []LogLevelOverride{{Pattern: "*:DEBUG"}},
+	}
+	diagnostics := profile.Validate()
+	require.False(t, diagnostics.HasErrors())
+	require.Equal(t, 4, len(diagnostics))
+
+	require.Equal(t, 3, diagnostics[0].RuleIndex)
+	require.Contains(t, diagnostics[0].Message, "empty matching string")
+
+	require.Equal(t, 0, diagnostics[1].RuleIndex)
+	require.Equal(t, "rule shadowed by the later rule 1 '*:INFO'", diagnostics[1].Message)
+
+	require.Equal(t, 2, diagnostics[2].RuleIndex)
+	require.Equal(t, "nonexistent", diagnostics[2].Token)
+	require.Equal(t, DiagnosticWarning, diagnostics[2].Severity)
+
+	require.Equal(t, "LogLevelOverrides[0]", diagnostics[3].Field)
+	require.Contains(t, diagnostics[3].Message, "expired")
+}
+
+func TestProfile_ApplyInvalidProfileShouldNotChangeAnything(t *testing.T) {
+	defer resetLogLevels()
+
+	_ = SetLogLevel("*:WARN")
+	profile := Profile{
+		LogLevelPatterns:  "*:DEBUG",
+		WithLoggerName:    true,
+		LogLevelOverrides: []LogLevelOverride{{Pattern: "*:TRACEE", Remaining: time.Minute}},
+	}
+
+	err := profile.Apply()
+	require.True(t, errors.Is(err, ErrInvalidProfile))
+	require.Equal(t, "*:WARN", GetLogLevelPattern())
+	require.False(t, GetCurrentProfile().WithLoggerName)
+}
+
+func TestProfile_ValidateInvalidCorrelationFilterShouldErr(t *testing.T) {
+	profile := Profile{LogLevelPatterns: "*:INFO", CorrelationFilter: "epoch>=twelve"}
+	diagnostics := profile.Validate()
+	require.Equal(t, 1, len(diagnostics))
+	require.Equal(t, "CorrelationFilter", diagnostics[0].Field)
+	require.True(t, profile.Apply() != nil)
+	require.Empty(t, GetCorrelationFilter())
+}
+
+func TestProfile_ValidateInvalidLineSuppressionShouldReportEachRule(t *testing.T) {
+	profile := Profile{
+		LogLevelPatterns: "*:INFO",
+		RateLimits: []RateLimit{
+			{Pattern: "p2p", LinesPerSecond: 10},
+			{Pattern: "consensus", LinesPerSecond: -1},
+		},
+		SamplingRules: []SamplingRule{
+			{Pattern: "", First: 1},
+		},
+		SuppressionReportInterval: -time.Second,
+	}
+	diagnostics := profile.Validate()
+	require.Equal(t, 3, len(diagnostics))
+
+	require.Equal(t, "RateLimits", diagnostics[0].Field)
+	require.Equal(t, 1, diagnostics[0].RuleIndex)
+	require.Equal(t, "consensus", diagnostics[0].Token)
+
+	require.Equal(t, "SamplingRules", diagnostics[1].Field)
+	require.Equal(t, 0, diagnostics[1].RuleIndex)
+
+	require.Equal(t, "SuppressionReportInterval", diagnostics[2].Field)
+	require.True(t, errors.Is(diagnostics.Err(), ErrInvalidProfile))
+}
@@ -32,6 +32,10 @@ type LogLineMessage struct {
 	Timestamp   int64                 `protobuf:"varint,4,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
 	LoggerName  string                `protobuf:"bytes,5,opt,name=LoggerName,proto3" json:"LoggerName,omitempty"`
 	Correlation LogCorrelationMessage `protobuf:"bytes,6,opt,name=Correlation,proto3" json:"Correlation"`
+	TraceID     string                `protobuf:"bytes,7,opt,name=TraceID,proto3" json:"TraceID,omitempty"`
+	SpanID      string                `protobuf:"bytes,8,opt,name=SpanID,proto3" json:"SpanID,omitempty"`
+	Caller      *LogCallerMessage     `protobuf:"bytes,9,opt,name=Caller,proto3" json:"Caller,omitempty"`
+	Stack       []string              `protobuf:"bytes,10,rep,name=Stack,proto3" json:"Stack,omitempty"`
 }
 
 func (m *LogLineMessage) Reset()      { *m = LogLineMessage{} }
@@ -104,11 +108,40 @@ func (m *LogLineMessage) GetCorrelation() LogCorrelationMessage {
 	return LogCorrelationMessage{}
 }
 
+func (m *LogLineMessage) GetTraceID() string {
+	if m != nil {
+		return m.TraceID
+	}
+	return ""
+}
+
+func (m *LogLineMessage) GetSpanID() string {
+	if m != nil {
+		return m.SpanID
+	}
+	return ""
+}
+
+func (m *LogLineMessage) GetCaller() *LogCallerMessage {
+	if m != nil {
+		return m.Caller
+	}
+	return nil
+}
+
+func (m *LogLineMessage) GetStack() []string {
+	if m != nil {
+		return m.Stack
+	}
+	return nil
+}
+
 type LogCorrelationMessage struct {
-	Shard    string `protobuf:"bytes,1,opt,name=Shard,proto3" json:"Shard,omitempty"`
-	Epoch    uint32 `protobuf:"varint,2,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
-	Round    int64  `protobuf:"varint,3,opt,name=Round,proto3" json:"Round,omitempty"`
-	SubRound string `protobuf:"bytes,4,opt,name=SubRound,proto3" json:"SubRound,omitempty"`
+	Shard    string                  `protobuf:"bytes,1,opt,name=Shard,proto3" json:"Shard,omitempty"`
+	Epoch    uint32                  `protobuf:"varint,2,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	Round    int64                   `protobuf:"varint,3,opt,name=Round,proto3" json:"Round,omitempty"`
+	SubRound string                  `protobuf:"bytes,4,opt,name=SubRound,proto3" json:"SubRound,omitempty"`
+	Elements []LogCorrelationElement `protobuf:"bytes,5,rep,name=Elements,proto3" json:"Elements"`
 }
 
 func (m *LogCorrelationMessage) Reset()      { *m = LogCorrelationMessage{} }
@@ -167,37 +200,165 @@ func (m *LogCorrelationMessage) GetSubRound() string {
 	return ""
 }
 
+func (m *LogCorrelationMessage) GetElements() []LogCorrelationElement {
+	if m != nil {
+		return m.Elements
+	}
+	return nil
+}
+
+type LogCorrelationElement struct {
+	Key   string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (m *LogCorrelationElement) Reset()      { *m = LogCorrelationElement{} }
+func (*LogCorrelationElement) ProtoMessage() {}
+func (*LogCorrelationElement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc96a1223a5fcf02, []int{2}
+}
+func (m *LogCorrelationElement) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogCorrelationElement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *LogCorrelationElement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogCorrelationElement.Merge(m, src)
+}
+func (m *LogCorrelationElement) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogCorrelationElement) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogCorrelationElement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogCorrelationElement proto.InternalMessageInfo
+
+func (m *LogCorrelationElement) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LogCorrelationElement) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type LogCallerMessage struct {
+	File     string `protobuf:"bytes,1,opt,name=File,proto3" json:"File,omitempty"`
+	Line     int32  `protobuf:"varint,2,opt,name=Line,proto3" json:"Line,omitempty"`
+	Function string `protobuf:"bytes,3,opt,name=Function,proto3" json:"Function,omitempty"`
+	Package  string `protobuf:"bytes,4,opt,name=Package,proto3" json:"Package,omitempty"`
+}
+
+func (m *LogCallerMessage) Reset()      { *m = LogCallerMessage{} }
+func (*LogCallerMessage) ProtoMessage() {}
+func (*LogCallerMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc96a1223a5fcf02, []int{3}
+}
+func (m *LogCallerMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogCallerMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *LogCallerMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogCallerMessage.Merge(m, src)
+}
+func (m *LogCallerMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogCallerMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogCallerMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogCallerMessage proto.InternalMessageInfo
+
+func (m *LogCallerMessage) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *LogCallerMessage) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *LogCallerMessage) GetFunction() string {
+	if m != nil {
+		return m.Function
+	}
+	return ""
+}
+
+func (m *LogCallerMessage) GetPackage() string {
+	if m != nil {
+		return m.Package
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*LogLineMessage)(nil), "proto.LogLineMessage")
 	proto.RegisterType((*LogCorrelationMessage)(nil), "proto.LogCorrelationMessage")
+	proto.RegisterType((*LogCorrelationElement)(nil), "proto.LogCorrelationElement")
+	proto.RegisterType((*LogCallerMessage)(nil), "proto.LogCallerMessage")
 }
 
 func init() { proto.RegisterFile("logLineMessage.proto", fileDescriptor_dc96a1223a5fcf02) }
 
 var fileDescriptor_dc96a1223a5fcf02 = []byte{
-	// 338 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcd, 0x4e, 0xf2, 0x40,
-	0x14, 0x86, 0x7b, 0xbe, 0xb6, 0x7c, 0x32, 0x44, 0x17, 0x13, 0x4c, 0x26, 0x84, 0x1c, 0x1b, 0x56,
-	0xdd, 0x08, 0x89, 0x5e, 0x80, 0x11, 0x75, 0x57, 0x5d, 0x14, 0x57, 0xee, 0x5a, 0x18, 0x87, 0x26,
-	0x94, 0x21, 0xfd, 0x71, 0xed, 0x25, 0x78, 0x19, 0x5e, 0x0a, 0x4b, 0x96, 0xac, 0x88, 0x0c, 0x1b,
-	0x97, 0x5c, 0x82, 0xe9, 0x0c, 0x22, 0x26, 0xae, 0xce, 0x79, 0xde, 0xf3, 0xd3, 0xf3, 0x76, 0x48,
-	0x73, 0x22, 0x45, 0x90, 0x4c, 0xf9, 0x3d, 0xcf, 0xf3, 0x48, 0xf0, 0xee, 0x2c, 0x93, 0x85, 0xa4,
-	0xae, 0x0e, 0xad, 0x73, 0x91, 0x14, 0xe3, 0x32, 0xee, 0x0e, 0x65, 0xda, 0x13, 0x52, 0xc8, 0x9e,
-	0x96, 0xe3, 0xf2, 0x59, 0x93, 0x06, 0x9d, 0x99, 0xa9, 0xce, 0x0a, 0xc8, 0x49, 0xf0, 0x6b, 0x1d,
-	0x65, 0xe4, 0xff, 0x2e, 0x65, 0xe0, 0x81, 0x5f, 0x0f, 0xbf, 0x91, 0xb6, 0xc8, 0x51, 0xd5, 0xcb,
-	0x5f, 0xf8, 0x84, 0xfd, 0xf3, 0xc0, 0x77, 0xc3, 0x3d, 0x53, 0x4a, 0x9c, 0xeb, 0x4c, 0xe4, 0xcc,
-	0xf6, 0x6c, 0xbf, 0x1e, 0xea, 0x9c, 0xb6, 0x49, 0xfd, 0x31, 0x49, 0x79, 0x5e, 0x44, 0xe9, 0x8c,
-	0x39, 0x1e, 0xf8, 0x76, 0xf8, 0x23, 0x50, 0x24, 0x24, 0x90, 0x42, 0xf0, 0xec, 0x21, 0x4a, 0x39,
-	0x73, 0xf5, 0xa7, 0x0e, 0x14, 0x7a, 0x4b, 0x1a, 0x37, 0x32, 0xcb, 0xf8, 0x24, 0x2a, 0x12, 0x39,
-	0x65, 0x35, 0x0f, 0xfc, 0xc6, 0x45, 0xdb, 0xdc, 0xdd, 0x0d, 0xa4, 0x38, 0x28, 0xee, 0x0e, 0xec,
-	0x3b, 0xf3, 0xd5, 0x99, 0x15, 0x1e, 0x8e, 0x75, 0x4a, 0x72, 0xfa, 0x67, 0x2f, 0x6d, 0x12, 0x77,
-	0x30, 0x8e, 0xb2, 0xd1, 0xce, 0xa4, 0x81, 0x4a, 0xbd, 0x9b, 0xc9, 0xe1, 0x58, 0xfb, 0x3b, 0x0e,
-	0x0d, 0x54, 0x6a, 0x28, 0xcb, 0xe9, 0x88, 0xd9, 0xda, 0x84, 0x81, 0xea, 0x77, 0x0c, 0xca, 0xd8,
-	0x14, 0x1c, 0xbd, 0x64, 0xcf, 0xfd, 0xab, 0xc5, 0x1a, 0xad, 0xe5, 0x1a, 0xad, 0xed, 0x1a, 0xe1,
-	0x55, 0x21, 0xbc, 0x2b, 0x84, 0xb9, 0x42, 0x58, 0x28, 0x84, 0xa5, 0x42, 0xf8, 0x50, 0x08, 0x9f,
-	0x0a, 0xad, 0xad, 0x42, 0x78, 0xdb, 0xa0, 0xb5, 0xd8, 0xa0, 0xb5, 0xdc, 0xa0, 0xf5, 0x64, 0xde,
-	0x31, 0xae, 0xe9, 0x70, 0xf9, 0x15, 0x00, 0x00, 0xff, 0xff, 0xaf, 0xd3, 0x9b, 0x1d, 0xed, 0x01,
-	0x00, 0x00,
+	// 494 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc7, 0xbd, 0x75, 0x9c, 0xc6, 0x5b, 0x81, 0xaa, 0x55, 0x81, 0x55, 0x55, 0x2d, 0x56, 0x4e,
+	0xbe, 0x90, 0x48, 0x70, 0xa7, 0xa2, 0x4d, 0x2b, 0x55, 0x04, 0x84, 0x9c, 0x8a, 0x03, 0xb7, 0x8d,
+	0xbb, 0x6c, 0xac, 0xda, 0x5e, 0xcb, 0x1f, 0x48, 0xdc, 0x78, 0x04, 0x1e, 0x83, 0x17, 0xe0, 0x1d,
+	0x7a, 0xcc, 0x8d, 0x9c, 0x10, 0x71, 0x2e, 0x1c, 0xfb, 0x08, 0x68, 0x67, 0x37, 0x21, 0x54, 0xf4,
+	0xe4, 0xf9, 0xcd, 0xcc, 0xee, 0xfc, 0x67, 0x76, 0x8c, 0x0f, 0x52, 0x25, 0xc7, 0x49, 0x2e, 0xde,
+	0x88, 0xaa, 0xe2, 0x52, 0x0c, 0x8a, 0x52, 0xd5, 0x8a, 0x78, 0xf0, 0x39, 0x7c, 0x26, 0x93, 0x7a,
+	0xd6, 0x4c, 0x07, 0xb1, 0xca, 0x86, 0x52, 0x49, 0x35, 0x04, 0xf7, 0xb4, 0xf9, 0x08, 0x04, 0x00,
+	0x96, 0x39, 0xd5, 0xff, 0xb1, 0x83, 0x1f, 0x8e, 0xff, 0xb9, 0x8e, 0x50, 0xbc, 0x6b, 0x4d, 0x8a,
+	0x02, 0x14, 0xfa, 0xd1, 0x1a, 0xc9, 0x21, 0xee, 0xe9, 0x5c, 0xf1, 0x49, 0xa4, 0x74, 0x27, 0x40,
+	0xa1, 0x17, 0x6d, 0x98, 0x10, 0xdc, 0x79, 0x55, 0xca, 0x8a, 0xba, 0x81, 0x1b, 0xfa, 0x11, 0xd8,
+	0xe4, 0x08, 0xfb, 0x97, 0x49, 0x26, 0xaa, 0x9a, 0x67, 0x05, 0xed, 0x04, 0x28, 0x74, 0xa3, 0xbf,
+	0x0e, 0xc2, 0x30, 0x1e, 0x2b, 0x29, 0x45, 0xf9, 0x96, 0x67, 0x82, 0x7a, 0x50, 0x6a, 0xcb, 0x43,
+	0x46, 0x78, 0xef, 0x54, 0x95, 0xa5, 0x48, 0x79, 0x9d, 0xa8, 0x9c, 0x76, 0x03, 0x14, 0xee, 0x3d,
+	0x3f, 0x32, 0xba, 0x07, 0x63, 0x25, 0xb7, 0x82, 0x56, 0xe0, 0x49, 0xe7, 0xe6, 0xe7, 0x53, 0x27,
+	0xda, 0x3e, 0xa6, 0xbb, 0xb9, 0x2c, 0x79, 0x2c, 0x2e, 0x46, 0x74, 0xd7, 0x74, 0x63, 0x91, 0x3c,
+	0xc6, 0xdd, 0x49, 0xc1, 0xf3, 0x8b, 0x11, 0xed, 0x41, 0xc0, 0x12, 0x19, 0xe2, 0xee, 0x29, 0x4f,
+	0x53, 0x51, 0x52, 0x1f, 0x4a, 0x3e, 0xd9, 0x2a, 0x09, 0x7e, 0x5b, 0x2d, 0xb2, 0x69, 0xe4, 0x00,
+	0x7b, 0x93, 0x9a, 0xc7, 0xd7, 0x14, 0x43, 0xef, 0x06, 0xfa, 0xdf, 0x11, 0x7e, 0xf4, 0x5f, 0x95,
+	0x90, 0x3f, 0xe3, 0xe5, 0x95, 0x1d, 0xaf, 0x01, 0xed, 0x3d, 0x2b, 0x54, 0x3c, 0x83, 0xc9, 0x3e,
+	0x88, 0x0c, 0x68, 0x6f, 0xa4, 0x9a, 0xfc, 0x8a, 0xba, 0x30, 0x3e, 0x03, 0xfa, 0x21, 0x26, 0xcd,
+	0xd4, 0x04, 0x3a, 0x70, 0xc9, 0x86, 0xc9, 0x4b, 0xdc, 0x3b, 0x4b, 0x45, 0x26, 0xf2, 0xba, 0xa2,
+	0x5e, 0xe0, 0xde, 0x3b, 0x33, 0x9b, 0x64, 0x67, 0xb6, 0x39, 0xd3, 0x3f, 0xbe, 0x2b, 0xdb, 0x46,
+	0xc8, 0x3e, 0x76, 0x5f, 0x8b, 0xcf, 0x56, 0xb4, 0x36, 0xb5, 0xb8, 0xf7, 0x3c, 0x6d, 0x04, 0x48,
+	0xf6, 0x23, 0x03, 0xfd, 0x02, 0xef, 0xdf, 0x1d, 0x95, 0xde, 0x8e, 0xf3, 0x24, 0x5d, 0x2f, 0x14,
+	0xd8, 0xda, 0xa7, 0xd7, 0xce, 0x6e, 0x12, 0xd8, 0xba, 0xb1, 0xf3, 0x26, 0x8f, 0xe1, 0xc1, 0x5d,
+	0xd3, 0xd8, 0x9a, 0xf5, 0x4b, 0xbe, 0xe3, 0xf1, 0xb5, 0xde, 0x4b, 0xd3, 0xf3, 0x1a, 0x4f, 0x8e,
+	0xe7, 0x4b, 0xe6, 0x2c, 0x96, 0xcc, 0xb9, 0x5d, 0x32, 0xf4, 0xa5, 0x65, 0xe8, 0x5b, 0xcb, 0xd0,
+	0x4d, 0xcb, 0xd0, 0xbc, 0x65, 0x68, 0xd1, 0x32, 0xf4, 0xab, 0x65, 0xe8, 0x77, 0xcb, 0x9c, 0xdb,
+	0x96, 0xa1, 0xaf, 0x2b, 0xe6, 0xcc, 0x57, 0xcc, 0x59, 0xac, 0x98, 0xf3, 0xc1, 0xfc, 0x34, 0xd3,
+	0x2e, 0x7c, 0x5e, 0xfc, 0x19, 0x00, 0x9c, 0xa2, 0x61, 0xc2, 0x5a, 0x03, 0x00, 0x00,
 }
 
 func (this *LogLineMessage) Equal(that interface{}) bool {
@@ -242,6 +403,23 @@ func (this *LogLineMessage) Equal(that interface{}) bool {
 	if !this.Correlation.Equal(&that1.Correlation) {
 		return false
 	}
+	if this.TraceID != that1.TraceID {
+		return false
+	}
+	if this.SpanID != that1.SpanID {
+		return false
+	}
+	if !this.Caller.Equal(that1.Caller) {
+		return false
+	}
+	if len(this.Stack) != len(that1.Stack) {
+		return false
+	}
+	for i := range this.Stack {
+		if this.Stack[i] != that1.Stack[i] {
+			return false
+		}
+	}
 	return true
 }
 func (this *LogCorrelationMessage) Equal(that interface{}) bool {
@@ -275,13 +453,81 @@ func (this *LogCorrelationMessage) Equal(that interface{}) bool {
 	if this.SubRound != that1.SubRound {
 		return false
 	}
+	if len(this.Elements) != len(that1.Elements) {
+		return false
+	}
+	for i := range this.Elements {
+		if !this.Elements[i].Equal(&that1.Elements[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *LogCorrelationElement) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogCorrelationElement)
+	if !ok {
+		that2, ok := that.(LogCorrelationElement)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Key != that1.Key {
+		return false
+	}
+	if this.Value != that1.Value {
+		return false
+	}
+	return true
+}
+func (this *LogCallerMessage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogCallerMessage)
+	if !ok {
+		that2, ok := that.(LogCallerMessage)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.File != that1.File {
+		return false
+	}
+	if this.Line != that1.Line {
+		return false
+	}
+	if this.Function != that1.Function {
+		return false
+	}
+	if this.Package != that1.Package {
+		return false
+	}
 	return true
 }
 func (this *LogLineMessage) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 10)
+	s := make([]string, 0, 14)
 	s = append(s, "&proto.LogLineMessage{")
 	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
 	s = append(s, "LogLevel: "+fmt.Sprintf("%#v", this.LogLevel)+",\n")
@@ -289,6 +535,12 @@ func (this *LogLineMessage) GoString() string {
 	s = append(s, "Timestamp: "+fmt.Sprintf("%#v", this.Timestamp)+",\n")
 	s = append(s, "LoggerName: "+fmt.Sprintf("%#v", this.LoggerName)+",\n")
 	s = append(s, "Correlation: "+strings.Replace(this.Correlation.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "TraceID: "+fmt.Sprintf("%#v", this.TraceID)+",\n")
+	s = append(s, "SpanID: "+fmt.Sprintf("%#v", this.SpanID)+",\n")
+	if this.Caller != nil {
+		s = append(s, "Caller: "+fmt.Sprintf("%#v", this.Caller)+",\n")
+	}
+	s = append(s, "Stack: "+fmt.Sprintf("%#v", this.Stack)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -296,12 +548,43 @@ func (this *LogCorrelationMessage) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 8)
+	s := make([]string, 0, 9)
 	s = append(s, "&proto.LogCorrelationMessage{")
 	s = append(s, "Shard: "+fmt.Sprintf("%#v", this.Shard)+",\n")
 	s = append(s, "Epoch: "+fmt.Sprintf("%#v", this.Epoch)+",\n")
 	s = append(s, "Round: "+fmt.Sprintf("%#v", this.Round)+",\n")
 	s = append(s, "SubRound: "+fmt.Sprintf("%#v", this.SubRound)+",\n")
+	if this.Elements != nil {
+		vs := make([]LogCorrelationElement, len(this.Elements))
+		for i := range vs {
+			vs[i] = this.Elements[i]
+		}
+		s = append(s, "Elements: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LogCorrelationElement) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.LogCorrelationElement{")
+	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LogCallerMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&proto.LogCallerMessage{")
+	s = append(s, "File: "+fmt.Sprintf("%#v", this.File)+",\n")
+	s = append(s, "Line: "+fmt.Sprintf("%#v", this.Line)+",\n")
+	s = append(s, "Function: "+fmt.Sprintf("%#v", this.Function)+",\n")
+	s = append(s, "Package: "+fmt.Sprintf("%#v", this.Package)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -333,6 +616,41 @@ func (m *LogLineMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Stack) > 0 {
+		for iNdEx := len(m.Stack) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Stack[iNdEx])
+			copy(dAtA[i:], m.Stack[iNdEx])
+			i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Stack[iNdEx])))
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if m.Caller != nil {
+		{
+			size, err := m.Caller.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogLineMessage(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.SpanID) > 0 {
+		i -= len(m.SpanID)
+		copy(dAtA[i:], m.SpanID)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.SpanID)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.TraceID) > 0 {
+		i -= len(m.TraceID)
+		copy(dAtA[i:], m.TraceID)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.TraceID)))
+		i--
+		dAtA[i] = 0x3a
+	}
 	{
 		size, err := m.Correlation.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -399,6 +717,20 @@ func (m *LogCorrelationMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Elements) > 0 {
+		for iNdEx := len(m.Elements) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Elements[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLogLineMessage(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if len(m.SubRound) > 0 {
 		i -= len(m.SubRound)
 		copy(dAtA[i:], m.SubRound)
@@ -426,6 +758,92 @@ func (m *LogCorrelationMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *LogCorrelationElement) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogCorrelationElement) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogCorrelationElement) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogCallerMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogCallerMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogCallerMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Package) > 0 {
+		i -= len(m.Package)
+		copy(dAtA[i:], m.Package)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Package)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Function) > 0 {
+		i -= len(m.Function)
+		copy(dAtA[i:], m.Function)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.Function)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Line != 0 {
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(m.Line))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.File) > 0 {
+		i -= len(m.File)
+		copy(dAtA[i:], m.File)
+		i = encodeVarintLogLineMessage(dAtA, i, uint64(len(m.File)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintLogLineMessage(dAtA []byte, offset int, v uint64) int {
 	offset -= sovLogLineMessage(v)
 	base := offset
@@ -465,16 +883,34 @@ func (m *LogLineMessage) Size() (n int) {
 	}
 	l = m.Correlation.Size()
 	n += 1 + l + sovLogLineMessage(uint64(l))
-	return n
-}
-
-func (m *LogCorrelationMessage) Size() (n int) {
-	if m == nil {
-		return 0
+	l = len(m.TraceID)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
 	}
-	var l int
-	_ = l
-	l = len(m.Shard)
+	l = len(m.SpanID)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	if m.Caller != nil {
+		l = m.Caller.Size()
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	if len(m.Stack) > 0 {
+		for _, s := range m.Stack {
+			l = len(s)
+			n += 1 + l + sovLogLineMessage(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LogCorrelationMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Shard)
 	if l > 0 {
 		n += 1 + l + sovLogLineMessage(uint64(l))
 	}
@@ -488,6 +924,53 @@ func (m *LogCorrelationMessage) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovLogLineMessage(uint64(l))
 	}
+	if len(m.Elements) > 0 {
+		for _, e := range m.Elements {
+			l = e.Size()
+			n += 1 + l + sovLogLineMessage(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LogCorrelationElement) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *LogCallerMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.File)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	if m.Line != 0 {
+		n += 1 + sovLogLineMessage(uint64(m.Line))
+	}
+	l = len(m.Function)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
+	l = len(m.Package)
+	if l > 0 {
+		n += 1 + l + sovLogLineMessage(uint64(l))
+	}
 	return n
 }
 
@@ -508,6 +991,10 @@ func (this *LogLineMessage) String() string {
 		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
 		`LoggerName:` + fmt.Sprintf("%v", this.LoggerName) + `,`,
 		`Correlation:` + strings.Replace(strings.Replace(this.Correlation.String(), "LogCorrelationMessage", "LogCorrelationMessage", 1), `&`, ``, 1) + `,`,
+		`TraceID:` + fmt.Sprintf("%v", this.TraceID) + `,`,
+		`SpanID:` + fmt.Sprintf("%v", this.SpanID) + `,`,
+		`Caller:` + strings.Replace(this.Caller.String(), "LogCallerMessage", "LogCallerMessage", 1) + `,`,
+		`Stack:` + fmt.Sprintf("%v", this.Stack) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -516,11 +1003,41 @@ func (this *LogCorrelationMessage) String() string {
 	if this == nil {
 		return "nil"
 	}
+	repeatedStringForElements := "[]LogCorrelationElement{"
+	for _, f := range this.Elements {
+		repeatedStringForElements += strings.Replace(strings.Replace(f.String(), "LogCorrelationElement", "LogCorrelationElement", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForElements += "}"
 	s := strings.Join([]string{`&LogCorrelationMessage{`,
 		`Shard:` + fmt.Sprintf("%v", this.Shard) + `,`,
 		`Epoch:` + fmt.Sprintf("%v", this.Epoch) + `,`,
 		`Round:` + fmt.Sprintf("%v", this.Round) + `,`,
 		`SubRound:` + fmt.Sprintf("%v", this.SubRound) + `,`,
+		`Elements:` + repeatedStringForElements + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogCorrelationElement) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogCorrelationElement{`,
+		`Key:` + fmt.Sprintf("%v", this.Key) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogCallerMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogCallerMessage{`,
+		`File:` + fmt.Sprintf("%v", this.File) + `,`,
+		`Line:` + fmt.Sprintf("%v", this.Line) + `,`,
+		`Function:` + fmt.Sprintf("%v", this.Function) + `,`,
+		`Package:` + fmt.Sprintf("%v", this.Package) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -729,6 +1246,138 @@ func (m *LogLineMessage) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpanID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Caller", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Caller == nil {
+				m.Caller = &LogCallerMessage{}
+			}
+			if err := m.Caller.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stack", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Stack = append(m.Stack, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLogLineMessage(dAtA[iNdEx:])
@@ -881,6 +1530,319 @@ func (m *LogCorrelationMessage) Unmarshal(dAtA []byte) error {
 			}
 			m.SubRound = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elements", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Elements = append(m.Elements, LogCorrelationElement{})
+			if err := m.Elements[len(m.Elements)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogLineMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogCorrelationElement) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogLineMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogCorrelationElement: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogCorrelationElement: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogLineMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogCallerMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogLineMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogCallerMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogCallerMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.File = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Line", wireType)
+			}
+			m.Line = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Line |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Function", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Function = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Package", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogLineMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogLineMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Package = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLogLineMessage(dAtA[iNdEx:])
@@ -0,0 +1,228 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// The redaction strategies of the RedactionRule
+const (
+	RedactFull    = "full"
+	RedactPartial = "partial"
+	RedactHash    = "hash"
+)
+
+// RedactedValue replaces the values redacted by the RedactFull strategy
+const RedactedValue = "[REDACTED]"
+
+// minPartialRedactionLength is the minimum length of a value partially redacted, the shorter ones being fully redacted
+const minPartialRedactionLength = 12
+const partialRedactionKept = 4
+const hashRedactionPrefix = "sha256:"
+const hashRedactionBytes = 8
+
+// RedactionRule masks the sensitive data found in the log line arguments, before any formatter, observer or parent
+// process sees it. A rule matches either the values following the arguments named by Key (an exact name or a glob
+// such as "*token*", case insensitive), or the parts of the arguments matching the Value regular expression, or the
+// arguments implementing the Sensitive interface. The Strategy is RedactFull (the default), RedactPartial (keeping
+// the first and last 4 characters) or RedactHash (a short SHA-256 digest, so that the equal values can be correlated).
+type RedactionRule struct {
+	Key       string `json:",omitempty"`
+	Value     string `json:",omitempty"`
+	Sensitive bool   `json:",omitempty"`
+	Strategy  string `json:",omitempty"`
+}
+
+// redactionPolicy holds the parsed redaction rules. It is never changed once built.
+type redactionPolicy struct {
+	rules             []RedactionRule
+	keyRules          []RedactionRule
+	valueRules        []RedactionRule
+	valueRegexes      []*regexp.Regexp
+	sensitiveStrategy string
+}
+
+var mutRedaction = &sync.RWMutex{}
+var redaction = &redactionPolicy{
+	sensitiveStrategy: RedactFull,
+}
+
+// SetRedactionRules replaces the redaction rules. An empty slice removes them, while the arguments implementing
+// the Sensitive interface are still fully redacted.
+func SetRedactionRules(rules []RedactionRule) error {
+	err := setRedactionRules(rules)
+	if err != nil {
+		return err
+	}
+
+	onProfileMutated(ProfileChangeSourceSetRedactionRules)
+	return nil
+}
+
+// GetRedactionRules returns a copy of the redaction rules
+func GetRedactionRules() []RedactionRule {
+	return copyRedactionRules(getRedactionPolicy().rules)
+}
+
+func setRedactionRules(rules []RedactionRule) error {
+	policy, err := newRedactionPolicy(rules)
+	if err != nil {
+		return err
+	}
+
+	mutRedaction.Lock()
+	redaction = policy
+	mutRedaction.Unlock()
+
+	return nil
+}
+
+func getRedactionPolicy() *redactionPolicy {
+	mutRedaction.RLock()
+	defer mutRedaction.RUnlock()
+
+	return redaction
+}
+
+func validateRedactionRules(rules []RedactionRule) error {
+	_, err := newRedactionPolicy(rules)
+	return err
+}
+
+func newRedactionPolicy(rules []RedactionRule) (*redactionPolicy, error) {
+	policy := &redactionPolicy{
+		rules:             copyRedactionRules(rules),
+		sensitiveStrategy: RedactFull,
+	}
+
+	isSensitiveStrategySet := false
+	for i, rule := range rules {
+		element := fmt.Sprintf("%s[%d]", fieldRedactionRules, i)
+		switch rule.Strategy {
+		case "", RedactFull, RedactPartial, RedactHash:
+		default:
+			return nil, createErrInvalidRedactionRule(element, fmt.Sprintf("unknown strategy '%s'", rule.Strategy))
+		}
+
+		numMatchers := 0
+		if len(rule.Key) > 0 {
+			numMatchers++
+		}
+		if len(rule.Value) > 0 {
+			numMatchers++
+		}
+		if rule.Sensitive {
+			numMatchers++
+		}
+		if numMatchers != 1 {
+			return nil, createErrInvalidRedactionRule(element, "exactly one of Key, Value and Sensitive must be set")
+		}
+
+		switch {
+		case len(rule.Key) > 0:
+			_, err := path.Match(rule.Key, "")
+			if err != nil {
+				return nil, createErrInvalidRedactionRule(element, fmt.Sprintf("invalid key glob '%s'", rule.Key))
+			}
+			policy.keyRules = append(policy.keyRules, rule)
+		case len(rule.Value) > 0:
+			regex, err := regexp.Compile(rule.Value)
+			if err != nil {
+				return nil, createErrInvalidRedactionRule(element, err.Error())
+			}
+			policy.valueRules = append(policy.valueRules, rule)
+			policy.valueRegexes = append(policy.valueRegexes, regex)
+		case !isSensitiveStrategySet:
+			policy.sensitiveStrategy = rule.Strategy
+			isSensitiveStrategySet = true
+		}
+	}
+
+	return policy, nil
+}
+
+// redactArg returns the redacted form of the provided argument: obj is the argument itself, value its string form and
+// key the string form of the preceding argument (the arguments are key-value pairs), empty for the keys
+func (policy *redactionPolicy) redactArg(obj interface{}, value string, key string, isValue bool) string {
+	_, isSensitive := obj.(Sensitive)
+	if isSensitive {
+		return redactValue(value, policy.sensitiveStrategy)
+	}
+
+	if isValue {
+		for _, rule := range policy.keyRules {
+			if isMatchingKeyPattern(key, rule.Key) {
+				return redactValue(value, rule.Strategy)
+			}
+		}
+	}
+
+	for i, regex := range policy.valueRegexes {
+		strategy := policy.valueRules[i].Strategy
+		value = regex.ReplaceAllStringFunc(value, func(match string) string {
+			return redactValue(match, strategy)
+		})
+	}
+
+	return value
+}
+
+// isMatchingKeyPattern returns true if the argument key matches the provided exact name or glob, case insensitive
+func isMatchingKeyPattern(key string, pattern string) bool {
+	isMatching, _ := path.Match(strings.ToLower(pattern), strings.ToLower(key))
+	return isMatching
+}
+
+func redactValue(value string, strategy string) string {
+	switch strategy {
+	case RedactPartial:
+		runes := []rune(value)
+		if len(runes) < minPartialRedactionLength {
+			return RedactedValue
+		}
+
+		return string(runes[:partialRedactionKept]) + "****" + string(runes[len(runes)-partialRedactionKept:])
+	case RedactHash:
+		hash := sha256.Sum256([]byte(value))
+		return hashRedactionPrefix + hex.EncodeToString(hash[:hashRedactionBytes])
+	default:
+		return RedactedValue
+	}
+}
+
+func copyRedactionRules(rules []RedactionRule) []RedactionRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	copied := make([]RedactionRule, len(rules))
+	copy(copied, rules)
+
+	return copied
+}
+
+func formatRedactionRules(rules []RedactionRule) string {
+	formatted := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		strategy := rule.Strategy
+		if len(strategy) == 0 {
+			strategy = RedactFull
+		}
+
+		switch {
+		case len(rule.Key) > 0:
+			formatted = append(formatted, fmt.Sprintf("key %s %s", rule.Key, strategy))
+		case len(rule.Value) > 0:
+			formatted = append(formatted, fmt.Sprintf("value %s %s", rule.Value, strategy))
+		default:
+			formatted = append(formatted, fmt.Sprintf("sensitive %s", strategy))
+		}
+	}
+
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testPrivateKey = "4f3edf983ac636a65a842ce7c78d9aa706d3b113bce9c46f30d7d21715b23b1d"
+
+type sensitiveMnemonic string
+
+func (sm sensitiveMnemonic) LogSensitive() {
+}
+
+func convertArgs(args ...interface{}) []string {
+	logLine := newLogLine("test", GetCorrelation(), "message", LogInfo, args...)
+
+	return convertLogLine(logLine, NewArgRendererRegistry()).GetArgs()
+}
+
+func TestSetRedactionRules_InvalidRulesShouldErr(t *testing.T) {
+	defer func() {
+		_ = SetRedactionRules(nil)
+	}()
+
+	invalidRules := [][]RedactionRule{
+		{{}},
+		{{Key: "token", Sensitive: true}},
+		{{Key: "token", Strategy: "blur"}},
+		{{Key: "[token"}},
+		{{Value: "(unclosed"}},
+	}
+	for _, rules := range invalidRules {
+		err := SetRedactionRules(rules)
+		require.True(t, errors.Is(err, ErrInvalidRedactionRule), err)
+	}
+
+	rules := []RedactionRule{{Key: "token"}}
+	require.Nil(t, SetRedactionRules(rules))
+	require.Equal(t, rules, GetRedactionRules())
+	require.NotNil(t, SetRedactionRules([]RedactionRule{{Value: "(unclosed"}}))
+	require.Equal(t, rules, GetRedactionRules())
+}
+
+func TestConvertLogLine_ShouldRedactTheArguments(t *testing.T) {
+	defer func() {
+		_ = SetRedactionRules(nil)
+	}()
+
+	err := SetRedactionRules([]RedactionRule{
+		{Key: "privateKey"},
+		{Key: "*token*", Strategy: RedactPartial},
+		{Key: "password", Strategy: RedactHash},
+		{Value: `[0-9a-f]{64}`, Strategy: RedactPartial},
+	})
+	require.Nil(t, err)
+
+	args := convertArgs(
+		"PRIVATEKEY", []byte{1, 2, 3},
+		"auth_token", "Bearer abcdefghijkl",
+		"refreshToken", "short",
+		"password", "secret",
+		"url", "https://node/key/"+testPrivateKey+"?x=1",
+		"privateKey",
+	)
+	require.Equal(t, []string{
+		"PRIVATEKEY", RedactedValue,
+		"auth_token", "Bear****ijkl",
+		"refreshToken", RedactedValue,
+		"password", args[7],
+		"url", "https://node/key/4f3e****3b1d?x=1",
+		"privateKey",
+	}, args)
+	require.True(t, strings.HasPrefix(args[7], hashRedactionPrefix))
+	require.Equal(t, len(hashRedactionPrefix)+2*hashRedactionBytes, len(args[7]))
+	require.Equal(t, args[7], convertArgs("password", "secret")[1])
+	require.NotEqual(t, args[7], convertArgs("password", "other")[1])
+}
+
+func TestConvertLogLine_SensitiveArgumentsShouldAlwaysBeRedacted(t *testing.T) {
+	defer func() {
+		_ = SetRedactionRules(nil)
+	}()
+
+	mnemonic := sensitiveMnemonic("abandon ability able about above absent")
+	require.Equal(t, []string{"words", RedactedValue, RedactedValue}, convertArgs("words", mnemonic, mnemonic))
+
+	err := SetRedactionRules([]RedactionRule{{Sensitive: true, Strategy: RedactPartial}})
+	require.Nil(t, err)
+	require.Equal(t, []string{"words", "aban****sent"}, convertArgs("words", mnemonic))
+}
+
+func TestProfile_ShouldCarryTheRedactionRules(t *testing.T) {
+	defer func() {
+		_ = SetRedactionRules(nil)
+	}()
+
+	profile := GetCurrentProfile()
+	profile.RedactionRules = []RedactionRule{{Key: "mnemonic"}, {Sensitive: true, Strategy: RedactHash}}
+	require.Contains(t, profile.String(), "redaction=[key mnemonic full, sensitive hash]")
+
+	data, err := profile.Marshal()
+	require.Nil(t, err)
+	unmarshaled, err := UnmarshalProfile(data)
+	require.Nil(t, err)
+	require.Nil(t, unmarshaled.Apply())
+	require.Equal(t, profile.RedactionRules, GetRedactionRules())
+
+	profile.RedactionRules = []RedactionRule{{Strategy: RedactFull}}
+	require.True(t, profile.Validate().HasErrors())
+	require.NotNil(t, profile.Apply())
+	require.Equal(t, 2, len(GetRedactionRules()))
+}
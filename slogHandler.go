@@ -0,0 +1,200 @@
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/kalyan3104/dme-logger-go/check"
+)
+
+const defaultSlogLoggerName = "slog"
+
+// SlogLoggerNameKey is the key of the slog attribute naming the logger a record is output on
+const SlogLoggerNameKey = "logger"
+
+var _ slog.Handler = (*slogHandler)(nil)
+
+// SlogHandlerOptions holds the options of the handlers created by NewSlogHandler
+type SlogHandlerOptions struct {
+	// LoggerName is the name of the logger the records are output on, unless they (or the handler, through
+	// WithAttrs) carry a "logger" attribute. Defaults to "slog".
+	LoggerName string
+}
+
+// slogHandler is a slog.Handler outputting the slog records as log lines. The records go either on the logger
+// named by the options or by the "logger" attribute (see GetOrCreate), or on the logger of a slog view (see
+// NewSlogLogger). The attributes become the line's arguments, their keys being prefixed with the groups they
+// belong to ("request.method"), while the trace and span IDs carried by the record's context are stamped on
+// the line.
+type slogHandler struct {
+	loggerName  string
+	log         Logger
+	args        []interface{}
+	groupPrefix string
+}
+
+// NewSlogHandler creates a slog.Handler outputting the slog records on the loggers obtained through GetOrCreate:
+//
+//	slog.SetDefault(slog.New(logger.NewSlogHandler(logger.SlogHandlerOptions{LoggerName: "api"})))
+//	slog.Info("request served", "logger", "api/http", "status", 200)
+func NewSlogHandler(options SlogHandlerOptions) *slogHandler {
+	loggerName := options.LoggerName
+	if len(loggerName) == 0 {
+		loggerName = defaultSlogLoggerName
+	}
+
+	return &slogHandler{
+		loggerName: loggerName,
+	}
+}
+
+// NewSlogLogger creates a slog.Logger view of the provided logger: its records are output on the provided logger,
+// keeping its derived options (such as the trace and span IDs of WithTrace)
+func NewSlogLogger(log Logger) (*slog.Logger, error) {
+	if check.IfNil(log) {
+		return nil, ErrNilLogger
+	}
+
+	return slog.New(&slogHandler{log: log}), nil
+}
+
+// LogLevelFromSlog converts a slog level into a log level. The slog levels are spaced by 4 while the priorities of the
+// built-in log levels are spaced by 10 (see LogLevel.Priority), so slog.LevelDebug-4 becomes TRACE, slog.LevelDebug
+// becomes DEBUG and so on up to slog.LevelError+8 becoming FATAL (without exiting the process). A custom slog level
+// such as NOTICE (slog.LevelInfo+2) maps to the priority 25 and becomes the registered level having the highest
+// priority up to 25, such as a NOTICE level registered with the priority 25 (see RegisterLogLevel).
+func LogLevelFromSlog(level slog.Level) LogLevel {
+	infoPriority := LogInfo.Priority()
+	priority := infoPriority + int(level-slog.LevelInfo)*(LogWarning.Priority()-infoPriority)/int(slog.LevelWarn-slog.LevelInfo)
+
+	return levelUpToPriority(priority)
+}
+
+// Enabled returns true if the logger of a slog view outputs the provided level. The records handled by the other
+// handlers can name their logger through the "logger" attribute, unknown at this point, so these handlers are enabled
+// as long as any logger outputs the level, Handle leaving the filtering to the record's logger.
+func (sh *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if sh.log != nil {
+		return LogLevelFromSlog(level).IsAtLeast(sh.log.GetLevel())
+	}
+
+	return LogLevelFromSlog(level).IsAtLeast(getLowestLogLevel())
+}
+
+// Handle outputs the provided record as a log line
+func (sh *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	loggerName := sh.loggerName
+	args := make([]interface{}, len(sh.args), len(sh.args)+2*record.NumAttrs())
+	copy(args, sh.args)
+	record.Attrs(func(attr slog.Attr) bool {
+		if sh.isLoggerNameAttr(attr) {
+			loggerName = attr.Value.Resolve().String()
+			return true
+		}
+
+		args = appendSlogAttr(args, sh.groupPrefix, attr)
+		return true
+	})
+
+	level := LogLevelFromSlog(record.Level)
+	log := LoggerWithContext(sh.logger(loggerName), ctx)
+	switch l := log.(type) {
+	case *logger:
+		l.outputMessage(level, lineOptions{pc: record.PC}, record.Message, args...)
+	case *derivedLogger:
+		options := l.options
+		options.pc = record.PC
+		l.outputMessage(level, options, record.Message, args...)
+	default:
+		LogAt(log, level, record.Message, args...)
+	}
+
+	return nil
+}
+
+// WithAttrs returns a handler adding the provided attributes to each record
+func (sh *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return sh
+	}
+
+	handler := sh.clone()
+	for _, attr := range attrs {
+		if handler.isLoggerNameAttr(attr) {
+			handler.loggerName = attr.Value.Resolve().String()
+			continue
+		}
+
+		handler.args = appendSlogAttr(handler.args, handler.groupPrefix, attr)
+	}
+
+	return handler
+}
+
+// WithGroup returns a handler nesting the following attributes into the provided group
+func (sh *slogHandler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return sh
+	}
+
+	handler := sh.clone()
+	handler.groupPrefix += name + "."
+
+	return handler
+}
+
+func (sh *slogHandler) clone() *slogHandler {
+	args := make([]interface{}, len(sh.args))
+	copy(args, sh.args)
+
+	return &slogHandler{
+		loggerName:  sh.loggerName,
+		log:         sh.log,
+		args:        args,
+		groupPrefix: sh.groupPrefix,
+	}
+}
+
+// logger returns the logger of a slog view or else the logger having the provided name
+func (sh *slogHandler) logger(loggerName string) Logger {
+	if sh.log != nil {
+		return sh.log
+	}
+
+	return GetOrCreate(loggerName)
+}
+
+// isLoggerNameAttr returns true for a top level "logger" attribute of a handler not bound to a logger
+func (sh *slogHandler) isLoggerNameAttr(attr slog.Attr) bool {
+	return sh.log == nil && len(sh.groupPrefix) == 0 && attr.Key == SlogLoggerNameKey
+}
+
+// appendSlogAttr appends the key and the value of the provided attribute to the arguments, flattening the groups
+func appendSlogAttr(args []interface{}, prefix string, attr slog.Attr) []interface{} {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return args
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if len(attr.Key) > 0 {
+			groupPrefix += attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			args = appendSlogAttr(args, groupPrefix, groupAttr)
+		}
+
+		return args
+	}
+
+	return append(args, prefix+attr.Key, attr.Value.Any())
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sh *slogHandler) IsInterfaceNil() bool {
+	return sh == nil
+}
@@ -0,0 +1,183 @@
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevelFromSlog(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, LogTrace, LogLevelFromSlog(slog.LevelDebug-4))
+	require.Equal(t, LogTrace, LogLevelFromSlog(slog.LevelDebug-100))
+	require.Equal(t, LogDebug, LogLevelFromSlog(slog.LevelDebug))
+	require.Equal(t, LogInfo, LogLevelFromSlog(slog.LevelInfo))
+	require.Equal(t, LogInfo, LogLevelFromSlog(slog.LevelInfo+1))
+	require.Equal(t, LogWarning, LogLevelFromSlog(slog.LevelWarn))
+	require.Equal(t, LogError, LogLevelFromSlog(slog.LevelError))
+	require.Equal(t, LogFatal, LogLevelFromSlog(slog.LevelError+8))
+	require.Equal(t, LogFatal, LogLevelFromSlog(slog.LevelError+1000))
+
+	// slog.LevelInfo+3 maps to the priority 27
+	audit := LogLevel(127)
+	require.Nil(t, RegisterLogLevel("SLOGAUDIT", audit, LogInfo.Priority()+7, ""))
+	defer UnregisterLogLevel(audit)
+	require.Equal(t, audit, LogLevelFromSlog(slog.LevelInfo+3))
+	require.Equal(t, audit, LogLevelFromSlog(slog.LevelWarn-1))
+}
+
+func TestNewSlogLogger_NilLoggerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	slogLogger, err := NewSlogLogger(nil)
+	require.Nil(t, slogLogger)
+	require.Equal(t, ErrNilLogger, err)
+}
+
+func TestSlogLogger_ShouldOutputTheRecordsOnTheLogger(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	log := NewLogger("test", LogInfo, capture)
+	slogLogger, _ := NewSlogLogger(log)
+
+	slogLogger.Debug("filtered out")
+	require.False(t, slogLogger.Enabled(context.Background(), slog.LevelDebug))
+	require.True(t, slogLogger.Enabled(context.Background(), slog.LevelInfo))
+
+	slogLogger.
+		With("node", "n1", SlogLoggerNameKey, "not a name").
+		WithGroup("request").
+		With("method", "GET").
+		Warn("served", "status", 200, slog.Group("peer", "id", "p1"), slog.Group("empty"))
+	require.Equal(t, 1, len(capture.lines))
+
+	line := capture.lines[0]
+	require.Equal(t, "test", line.LoggerName)
+	require.Equal(t, LogWarning, line.LogLevel)
+	require.Equal(t, "served", line.Message)
+	require.Equal(t, []interface{}{
+		"node", "n1",
+		SlogLoggerNameKey, "not a name",
+		"request.method", "GET",
+		"request.status", int64(200),
+		"request.peer.id", "p1",
+	}, line.Args)
+}
+
+func TestSlogLogger_ShouldStampTheTraceAndTheCaller(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	log := NewLogger("test", LogInfo, capture)
+	log.setCallerCapture(true)
+	log.setStackCapture(true)
+	slogLogger, _ := NewSlogLogger(log.WithTrace("trace", "span"))
+
+	ctx := ContextWithTrace(context.Background(), testTraceID, testSpanID)
+	slogLogger.Info("plain")
+	slogLogger.ErrorContext(ctx, "failed")
+	require.Equal(t, 2, len(capture.lines))
+
+	require.Equal(t, "trace", capture.lines[0].TraceID)
+	require.Equal(t, testTraceID, capture.lines[1].TraceID)
+	require.Equal(t, testSpanID, capture.lines[1].SpanID)
+
+	caller := capture.lines[1].Caller
+	require.NotNil(t, caller)
+	require.True(t, strings.HasSuffix(caller.File, "slogHandler_test.go"))
+	require.Equal(t, "TestSlogLogger_ShouldStampTheTraceAndTheCaller", caller.Function)
+
+	stack := capture.lines[1].Stack
+	require.NotEmpty(t, stack)
+	require.Contains(t, stack[0], "TestSlogLogger_ShouldStampTheTraceAndTheCaller")
+}
+
+func TestSlogHandler_ShouldOutputOnTheNamedLoggers(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	defaultLog := GetOrCreate("slog-test-default")
+	defaultLog.logOutput = capture
+	defaultLog.SetLevel(LogDebug)
+	namedLog := GetOrCreate("slog-test-named")
+	namedLog.logOutput = capture
+	namedLog.SetLevel(LogWarning)
+
+	handler := NewSlogHandler(SlogHandlerOptions{LoggerName: "slog-test-default"})
+	require.False(t, handler.IsInterfaceNil())
+	require.Equal(t, defaultSlogLoggerName, NewSlogHandler(SlogHandlerOptions{}).loggerName)
+	slogLogger := slog.New(handler)
+
+	slogLogger.Debug("default")
+	slogLogger.Info("filtered out", SlogLoggerNameKey, "slog-test-named")
+	slogLogger.Warn("named", SlogLoggerNameKey, "slog-test-named", "key", "value")
+
+	// the records can still name another logger, so the handler is enabled while the named logger filters
+	namedSlogLogger := slogLogger.With(SlogLoggerNameKey, "slog-test-named")
+	require.True(t, namedSlogLogger.Enabled(context.Background(), slog.LevelInfo))
+	namedSlogLogger.Info("filtered out by the named logger")
+	namedSlogLogger.Error("named by the handler")
+
+	require.Equal(t, 3, len(capture.lines))
+	require.Equal(t, "slog-test-default", capture.lines[0].LoggerName)
+	require.Equal(t, "slog-test-named", capture.lines[1].LoggerName)
+	require.Equal(t, []interface{}{"key", "value"}, capture.lines[1].Args)
+	require.Equal(t, "slog-test-named", capture.lines[2].LoggerName)
+	require.Empty(t, capture.lines[2].Args)
+}
+
+func TestSlogHandler_ShouldBeEnabledForTheMoreVerboseRoutedLoggers(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	defaultLog := GetOrCreate("slog-enabled-default")
+	defaultLog.logOutput = capture
+	defaultLog.SetLevel(LogInfo)
+	verboseLog := GetOrCreate("slog-enabled-verbose")
+	verboseLog.logOutput = capture
+	verboseLog.SetLevel(LogTrace)
+
+	slogLogger := slog.New(NewSlogHandler(SlogHandlerOptions{LoggerName: "slog-enabled-default"}))
+	require.True(t, slogLogger.Enabled(context.Background(), slog.LevelDebug-4))
+
+	slogLogger.Debug("filtered out")
+	slogLogger.Debug("routed", SlogLoggerNameKey, "slog-enabled-verbose")
+	require.Equal(t, 1, len(capture.lines))
+	require.Equal(t, "slog-enabled-verbose", capture.lines[0].LoggerName)
+	require.Equal(t, LogDebug, capture.lines[0].LogLevel)
+}
+
+func TestSlogHandler_EnabledShouldFollowTheLevelChanges(t *testing.T) {
+	defer resetLogLevels()
+
+	log := GetOrCreate("slog-lowest-level")
+	slogLogger := slog.New(NewSlogHandler(SlogHandlerOptions{LoggerName: "slog-lowest-level"}))
+	require.Nil(t, SetLogLevel("*:INFO"))
+	require.False(t, slogLogger.Enabled(context.Background(), slog.LevelDebug))
+
+	log.SetLevel(LogDebug)
+	require.True(t, slogLogger.Enabled(context.Background(), slog.LevelDebug))
+
+	require.Nil(t, SetLogLevel("*:INFO"))
+	require.False(t, slogLogger.Enabled(context.Background(), slog.LevelDebug))
+
+	require.Nil(t, SetLogLevelFor("slog-lowest-level:TRACE", time.Minute))
+	require.True(t, slogLogger.Enabled(context.Background(), slog.LevelDebug-4))
+
+	ClearLogLevelOverrides()
+	require.False(t, slogLogger.Enabled(context.Background(), slog.LevelDebug))
+
+	profile := GetCurrentProfile()
+	profile.LogLevelPatterns = "*:INFO,slog-lowest-level:DEBUG"
+	require.Nil(t, profile.Apply())
+	require.True(t, slogLogger.Enabled(context.Background(), slog.LevelDebug))
+}
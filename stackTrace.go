@@ -0,0 +1,164 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+const maxStackFrames = 64
+const maxErrorChainDepth = 32
+
+var stackTracePattern = ""
+var stackTraceMatchingStrings []string
+
+// SetStackTracePatterns enables the stack capture on the ERROR lines of the loggers whose names contain any of
+// the provided comma separated matching strings, "*" matching all the loggers. The loggers created afterwards are
+// also checked against them. An empty string disables the stack capture.
+func SetStackTracePatterns(patterns string) error {
+	err := setStackTracePatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	onProfileMutated(ProfileChangeSourceSetStackTracePatterns)
+	return nil
+}
+
+func setStackTracePatterns(patterns string) error {
+	matchingStrings, err := ParseStackTracePatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	logMut.Lock()
+	stackTracePattern = patterns
+	stackTraceMatchingStrings = matchingStrings
+	for name, log := range loggers {
+		log.setStackCapture(isStackCaptureMatching(name))
+	}
+	logMut.Unlock()
+
+	return nil
+}
+
+// GetStackTracePatterns returns the last set stack trace patterns
+func GetStackTracePatterns() string {
+	logMut.RLock()
+	defer logMut.RUnlock()
+
+	return stackTracePattern
+}
+
+// ParseStackTracePatterns splits the comma separated stack trace patterns into their matching strings. Errors if any
+// of the matching strings is empty.
+func ParseStackTracePatterns(patterns string) ([]string, error) {
+	matchingStrings, ok := splitMatchingStrings(patterns)
+	if !ok {
+		return nil, createErrInvalidStackTracePatterns(patterns, "empty matching string")
+	}
+
+	return matchingStrings, nil
+}
+
+// isStackCaptureMatching must be called under logMut
+func isStackCaptureMatching(loggerName string) bool {
+	return isMatchingAnyPattern(loggerName, stackTraceMatchingStrings)
+}
+
+// captureStack returns the frames of the current goroutine's stack, starting with the caller of the function
+// found skip frames above captureStack's caller or, if provided and found on the stack, with the frame of the
+// fromPC program counter
+func captureStack(skip int, fromPC uintptr) []string {
+	pcs := make([]uintptr, maxStackFrames)
+	// runtime.Callers and captureStack itself
+	n := runtime.Callers(skip+2, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	pcs = pcs[:n]
+	for i, pc := range pcs {
+		if pc == fromPC {
+			pcs = pcs[i:]
+			break
+		}
+	}
+
+	stack := make([]string, 0, len(pcs))
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
+
+// withErrorDetails walks the chain of the provided error (errors.Unwrap and the errors joining others through
+// an Unwrap() []error method), appending a "cause.N" argument for each wrapped error and the fields of the errors
+// implementing ErrorWithFields to a copy of the provided arguments. The stack of the first error implementing
+// ErrorWithStack is set on the returned options.
+func withErrorDetails(options lineOptions, err error, args []interface{}) (lineOptions, []interface{}) {
+	detailedArgs := make([]interface{}, len(args))
+	copy(detailedArgs, args)
+
+	numCauses := 0
+	// the root error is told apart by its depth, as the errors are not necessarily comparable
+	walkErrorChain(err, 0, func(current error, depth int) {
+		if depth > 0 {
+			numCauses++
+			detailedArgs = append(detailedArgs, fmt.Sprintf("cause.%d", numCauses), current.Error())
+		}
+
+		withFields, ok := current.(ErrorWithFields)
+		if ok {
+			detailedArgs = append(detailedArgs, withFields.LogFields()...)
+		}
+
+		withStack, ok := current.(ErrorWithStack)
+		if ok && len(options.stack) == 0 {
+			options.stack = withStack.LogStack()
+		}
+	})
+
+	return options, detailedArgs
+}
+
+func walkErrorChain(err error, depth int, visit func(err error, depth int)) {
+	if err == nil || depth > maxErrorChainDepth {
+		return
+	}
+
+	visit(err, depth)
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if ok {
+		for _, wrapped := range joined.Unwrap() {
+			walkErrorChain(wrapped, depth+1, visit)
+		}
+		return
+	}
+
+	walkErrorChain(errors.Unwrap(err), depth+1, visit)
+}
+
+// formatStack renders the stack frames as an indented block, one frame per line
+func formatStack(stack []string) string {
+	if len(stack) == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+	for _, frame := range stack {
+		builder.WriteString("\t")
+		builder.WriteString(frame)
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
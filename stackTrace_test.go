@@ -0,0 +1,166 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type joinedErrors struct {
+	errs []error
+}
+
+func (je *joinedErrors) Error() string {
+	messages := make([]string, len(je.errs))
+	for i, err := range je.errs {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (je *joinedErrors) Unwrap() []error {
+	return je.errs
+}
+
+type detailedError struct {
+	message string
+	stack   []string
+	fields  []interface{}
+}
+
+func (de *detailedError) Error() string {
+	return de.message
+}
+
+func (de *detailedError) LogStack() []string {
+	return de.stack
+}
+
+func (de *detailedError) LogFields() []interface{} {
+	return de.fields
+}
+
+func TestStackTrace_ShouldCaptureOnTheErrorLinesOfTheMatchingLoggers(t *testing.T) {
+	defer func() {
+		_ = SetStackTracePatterns("")
+	}()
+
+	capture := &lineCapture{}
+	log := GetOrCreate("stack-test/capture")
+	log.logOutput = capture
+
+	log.Error("disabled")
+	require.Nil(t, capture.lines[0].Stack)
+
+	err := SetStackTracePatterns("stack-test")
+	require.Nil(t, err)
+	require.Equal(t, "stack-test", GetStackTracePatterns())
+
+	log.Warn("warning")
+	require.Nil(t, capture.lines[1].Stack)
+
+	log.Error("error")
+	require.True(t, len(capture.lines[2].Stack) > 0)
+	require.True(t, strings.Contains(capture.lines[2].Stack[0], "TestStackTrace_ShouldCaptureOnTheErrorLinesOfTheMatchingLoggers"))
+	require.True(t, strings.Contains(capture.lines[2].Stack[0], "stackTrace_test.go:"))
+
+	log.LogIfError(errors.New("error"))
+	require.True(t, strings.Contains(capture.lines[3].Stack[0], "TestStackTrace_ShouldCaptureOnTheErrorLinesOfTheMatchingLoggers"))
+
+	require.True(t, GetOrCreate("stack-test/created").isStackCaptureEnabled())
+
+	_, err = ParseStackTracePatterns("a,,b")
+	require.True(t, errors.Is(err, ErrInvalidStackTracePatterns))
+}
+
+func TestLogger_LogIfErrorShouldUnwrapTheErrorChain(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	log := NewLogger("test", LogInfo, capture)
+
+	root := &detailedError{
+		message: "root",
+		stack:   []string{"frame1", "frame2"},
+		fields:  []interface{}{"nonce", 42},
+	}
+	other := errors.New("other")
+	wrapped := fmt.Errorf("processing: %w", &joinedErrors{errs: []error{root, other}})
+
+	args := make([]interface{}, 2, 10)
+	args[0] = "shard"
+	args[1] = 1
+	log.LogIfError(wrapped, args...)
+
+	require.Equal(t, 1, len(capture.lines))
+	line := capture.lines[0]
+	require.Equal(t, wrapped.Error(), line.Message)
+	require.Equal(t, []interface{}{
+		"shard", 1,
+		"cause.1", "root\nother",
+		"cause.2", "root",
+		"nonce", 42,
+		"cause.3", "other",
+	}, line.Args)
+	require.Equal(t, root.stack, line.Stack)
+	// the provided arguments are not altered
+	require.Equal(t, 2, len(args))
+	require.Nil(t, args[:3][2])
+
+	log.WithTrace("trace", "span").LogIfError(root)
+	require.Equal(t, []interface{}{"nonce", 42}, capture.lines[1].Args)
+	require.Equal(t, root.stack, capture.lines[1].Stack)
+}
+
+// uncomparableError is a value type error that cannot be compared with ==, because of its slice field
+type uncomparableError struct {
+	fields []string
+	cause  error
+}
+
+func (ue uncomparableError) Error() string {
+	return strings.Join(ue.fields, ",")
+}
+
+func (ue uncomparableError) Unwrap() error {
+	return ue.cause
+}
+
+func TestLogger_LogIfErrorUncomparableErrorsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	log := NewLogger("test", LogInfo, capture)
+
+	require.NotPanics(t, func() {
+		log.LogIfError(uncomparableError{fields: []string{"a"}})
+	})
+	require.Empty(t, capture.lines[0].Args)
+
+	cause := uncomparableError{fields: []string{"b"}}
+	log.LogIfError(uncomparableError{fields: []string{"a"}, cause: cause})
+	require.Equal(t, []interface{}{"cause.1", "b"}, capture.lines[1].Args)
+}
+
+func TestFormatters_ShouldRenderTheStack(t *testing.T) {
+	t.Parallel()
+
+	line := &LogLineWrapper{}
+	line.Message = "message"
+	line.Stack = []string{"pkg.A a.go:1", "pkg.B b.go:2"}
+
+	output := string((&PlainFormatter{}).Output(line))
+	require.True(t, strings.HasSuffix(output, "\n\tpkg.A a.go:1\n\tpkg.B b.go:2\n"), output)
+
+	output = string((&ConsoleFormatter{}).Output(line))
+	require.True(t, strings.HasSuffix(output, "\n\tpkg.A a.go:1\n\tpkg.B b.go:2\n"), output)
+
+	buff, err := json.Marshal(line)
+	require.Nil(t, err)
+	require.True(t, strings.Contains(string(buff), `"Stack":["pkg.A a.go:1","pkg.B b.go:2"]`))
+}
@@ -0,0 +1,142 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/check"
+	"github.com/kalyan3104/dme-logger-go/pipes"
+)
+
+var logClient = logger.GetOrCreate("stream/client")
+
+// client is the logs viewer part that connects to a streaming server, sends its profile and
+// outputs all received log lines on the provided log output handler
+type client struct {
+	url               string
+	profile           logger.Profile
+	marshalizer       logger.Marshalizer
+	outputSubject     logger.LogOutputHandler
+	reconnectInterval time.Duration
+	httpClient        *http.Client
+}
+
+// NewClient creates a new logs viewer client. A zero reconnect interval disables reconnecting.
+func NewClient(
+	serverURL string,
+	marshalizerName string,
+	profile logger.Profile,
+	outputSubject logger.LogOutputHandler,
+	reconnectInterval time.Duration,
+) (*client, error) {
+	if check.IfNil(outputSubject) {
+		return nil, ErrNilLogOutputHandler
+	}
+
+	marshalizer, err := GetMarshalizer(marshalizerName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, err = logger.ParseLogLevelAndMatchingString(profile.LogLevelPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedURL, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	query := parsedURL.Query()
+	query.Set(MarshalizerQueryParameter, marshalizerName)
+	parsedURL.RawQuery = query.Encode()
+
+	return &client{
+		url:               parsedURL.String(),
+		profile:           profile,
+		marshalizer:       marshalizer,
+		outputSubject:     outputSubject,
+		reconnectInterval: reconnectInterval,
+		httpClient:        &http.Client{},
+	}, nil
+}
+
+// Run connects to the server and outputs the received log lines until the context is done. When the connection
+// breaks, it reconnects after the configured interval (or returns the error, if reconnecting is disabled).
+func (c *client) Run(ctx context.Context) error {
+	for {
+		err := c.connectAndRead(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if c.reconnectInterval == 0 {
+			return err
+		}
+
+		logClient.Warn("connection to the logs streaming server lost, will reconnect",
+			"url", c.url, "err", err, "after", c.reconnectInterval)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.reconnectInterval):
+		}
+	}
+}
+
+func (c *client) connectAndRead(ctx context.Context) error {
+	data, err := c.profile.Marshal()
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, maxProfileSize))
+		return fmt.Errorf("%w: %s %s", ErrUnexpectedResponse, response.Status, bytes.TrimSpace(message))
+	}
+
+	logClient.Info("connected to the logs streaming server", "url", c.url)
+	return c.continuouslyRead(pipes.NewMessenger(response.Body, nil))
+}
+
+func (c *client) continuouslyRead(messenger *pipes.Messenger) error {
+	for {
+		buffer, err := messenger.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		wrapper := &logger.LogLineWrapper{}
+		err = c.marshalizer.Unmarshal(wrapper, buffer)
+		if err != nil {
+			return pipes.CreateErrUnmarshalLogLine(buffer, err)
+		}
+
+		c.outputSubject.Output(logger.RecoverLogLine(wrapper))
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (c *client) IsInterfaceNil() bool {
+	return c == nil
+}
@@ -0,0 +1,119 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createViewerOutput() (logger.LogOutputHandler, *mock.DummyLogsGatherer) {
+	gatherer := &mock.DummyLogsGatherer{}
+	viewerOutput := logger.NewLogOutputSubject()
+	_ = viewerOutput.AddObserver(gatherer, gatherer)
+
+	return viewerOutput, gatherer
+}
+
+// logUntilReceived keeps logging the message on the node's side, since the viewer connects asynchronously
+func logUntilReceived(t *testing.T, nodeLog logger.Logger, gatherer *mock.DummyLogsGatherer, message string) {
+	for i := 0; i < 100; i++ {
+		nodeLog.Info(message)
+		if gatherer.ContainsText(message) {
+			return
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	require.Fail(t, "log line not received by the viewer", message)
+}
+
+func TestNewClient(t *testing.T) {
+	profile := logger.Profile{LogLevelPatterns: "*:INFO"}
+
+	c, err := NewClient("http://127.0.0.1/log", MarshalizerJSON, profile, nil, 0)
+	require.Nil(t, c)
+	require.Equal(t, ErrNilLogOutputHandler, err)
+
+	c, err = NewClient("http://127.0.0.1/log", "xml", profile, logger.NewLogOutputSubject(), 0)
+	require.Nil(t, c)
+	require.True(t, errors.Is(err, ErrUnknownMarshalizer))
+
+	c, err = NewClient("http://127.0.0.1/log", MarshalizerJSON, logger.Profile{}, logger.NewLogOutputSubject(), 0)
+	require.Nil(t, c)
+	require.Equal(t, logger.ErrInvalidLogLevelPattern, err)
+
+	c, err = NewClient("http://127.0.0.1/log", MarshalizerGogoProto, profile, logger.NewLogOutputSubject(), 0)
+	require.Nil(t, err)
+	require.False(t, c.IsInterfaceNil())
+}
+
+func TestClient_EndToEnd(t *testing.T) {
+	nodeOutput := logger.NewLogOutputSubject()
+	s, _ := NewServer(nodeOutput, DefaultQueueSize)
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	viewerOutput, gatherer := createViewerOutput()
+	profile := logger.Profile{LogLevelPatterns: "*:INFO", WithLoggerName: true}
+	c, err := NewClient(httpServer.URL, MarshalizerGogoProto, profile, viewerOutput, 0)
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	chDone := make(chan error)
+	go func() {
+		chDone <- c.Run(ctx)
+	}()
+
+	nodeLog := logger.NewLogger("node", logger.LogTrace, nodeOutput)
+	logUntilReceived(t, nodeLog, gatherer, "first")
+	nodeLog.Debug("debug-no")
+	logUntilReceived(t, nodeLog, gatherer, "second")
+
+	require.True(t, gatherer.ContainsLogLine("node", logger.LogInfo, "second"))
+	require.False(t, gatherer.ContainsText("debug-no"))
+
+	cancel()
+	require.Equal(t, context.Canceled, <-chDone)
+}
+
+func TestClient_ShouldReconnect(t *testing.T) {
+	nodeOutput := logger.NewLogOutputSubject()
+	s, _ := NewServer(nodeOutput, DefaultQueueSize)
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	viewerOutput, gatherer := createViewerOutput()
+	profile := logger.Profile{LogLevelPatterns: "*:INFO"}
+	c, _ := NewClient(httpServer.URL, MarshalizerJSON, profile, viewerOutput, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = c.Run(ctx)
+	}()
+
+	nodeLog := logger.NewLogger("node", logger.LogTrace, nodeOutput)
+	logUntilReceived(t, nodeLog, gatherer, "before")
+
+	httpServer.CloseClientConnections()
+	logUntilReceived(t, nodeLog, gatherer, "after")
+}
+
+func TestClient_NoReconnectShouldReturnError(t *testing.T) {
+	httpServer := httptest.NewServer(&server{outputSubject: logger.NewLogOutputSubject(), queueSize: 1})
+	httpServer.Close()
+
+	viewerOutput, _ := createViewerOutput()
+	profile := logger.Profile{LogLevelPatterns: "*:INFO"}
+	c, _ := NewClient(httpServer.URL, MarshalizerJSON, profile, viewerOutput, 0)
+
+	err := c.Run(context.Background())
+	require.NotNil(t, err)
+}
@@ -0,0 +1,37 @@
+package stream
+
+import (
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/marshal"
+)
+
+const (
+	// MarshalizerJSON is the name of the JSON log line marshalizer
+	MarshalizerJSON = "json"
+	// MarshalizerGogoProto is the name of the gogo protobuf log line marshalizer
+	MarshalizerGogoProto = "gogo"
+)
+
+// MarshalizerQueryParameter is the URL query parameter used by a viewer to choose the log line marshalizer
+const MarshalizerQueryParameter = "marshalizer"
+
+// DefaultQueueSize is the default number of log lines buffered for each connected viewer
+const DefaultQueueSize = 1024
+
+// defaultViewerLogLevel is the level applied on loggers not matched by any of the viewer's patterns
+const defaultViewerLogLevel = logger.LogInfo
+
+const droppedLinesLoggerName = "stream"
+const droppedLinesMessage = "log lines dropped for slow viewer"
+
+// GetMarshalizer returns the log line marshalizer identified by the provided name
+func GetMarshalizer(name string) (logger.Marshalizer, error) {
+	switch name {
+	case "", MarshalizerJSON:
+		return &marshal.JSONMarshalizer{}, nil
+	case MarshalizerGogoProto:
+		return &marshal.GogoProtoMarshalizer{}, nil
+	default:
+		return nil, createErrUnknownMarshalizer(name)
+	}
+}
@@ -0,0 +1,25 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNilLogOutputHandler signals that a nil log output handler has been provided
+var ErrNilLogOutputHandler = errors.New("nil log output handler")
+
+// ErrInvalidQueueSize signals that an invalid queue size has been provided
+var ErrInvalidQueueSize = errors.New("invalid queue size")
+
+// ErrUnknownMarshalizer signals that an unknown marshalizer name has been provided
+var ErrUnknownMarshalizer = errors.New("unknown marshalizer")
+
+// ErrStreamingNotSupported signals that the underlying response writer is not able to flush data
+var ErrStreamingNotSupported = errors.New("streaming not supported by the response writer")
+
+// ErrUnexpectedResponse signals that the logs streaming server responded with an unexpected status
+var ErrUnexpectedResponse = errors.New("unexpected response from the logs streaming server")
+
+func createErrUnknownMarshalizer(name string) error {
+	return fmt.Errorf("%w '%s'", ErrUnknownMarshalizer, name)
+}
@@ -0,0 +1,103 @@
+package stream
+
+import (
+	"io"
+	"net/http"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/check"
+	"github.com/kalyan3104/dme-logger-go/pipes"
+)
+
+var _ http.Handler = (*server)(nil)
+
+var log = logger.GetOrCreate("stream/server")
+
+const maxProfileSize = 1 << 16
+
+// server streams log lines to remote logs viewers over chunked HTTP. A viewer POSTs its marshalized logger.Profile
+// and then continuously receives length-prefixed (see pipes.Messenger) marshalized log line wrappers.
+// Each viewer gets its own bounded queue: a slow viewer loses log lines but never blocks the node.
+// The viewer's level patterns filter the lines the node's loggers output at their own levels, so they can only narrow
+// the output: a viewer asking for "*:TRACE" on a node running with "*:INFO" receives the INFO lines and above.
+type server struct {
+	outputSubject logger.LogOutputHandler
+	queueSize     int
+}
+
+// NewServer creates a new logs streaming server, feeding on the provided log output subject. The viewers' level
+// patterns can only narrow the lines output by the node's loggers, whose levels are left unchanged.
+func NewServer(outputSubject logger.LogOutputHandler, queueSize int) (*server, error) {
+	if check.IfNil(outputSubject) {
+		return nil, ErrNilLogOutputHandler
+	}
+	if queueSize < 1 {
+		return nil, ErrInvalidQueueSize
+	}
+
+	return &server{
+		outputSubject: outputSubject,
+		queueSize:     queueSize,
+	}, nil
+}
+
+// ServeHTTP registers the requesting viewer as a log observer and streams log lines to it until it disconnects
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, ErrStreamingNotSupported.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	part, err := s.createViewerPart(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = s.outputSubject.AddObserver(part, part)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		_ = s.outputSubject.RemoveObserver(part)
+	}()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	log.Debug("logs viewer connected", "remote", r.RemoteAddr, "profile", part.profile.String())
+	err = part.continuouslySend(r.Context(), pipes.NewMessenger(nil, w), flusher)
+	log.Debug("logs viewer disconnected", "remote", r.RemoteAddr, "reason", err)
+}
+
+func (s *server) createViewerPart(r *http.Request) (*viewerPart, error) {
+	marshalizer, err := GetMarshalizer(r.URL.Query().Get(MarshalizerQueryParameter))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxProfileSize))
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := logger.UnmarshalProfile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return newViewerPart(profile, marshalizer, s.queueSize)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *server) IsInterfaceNil() bool {
+	return s == nil
+}
@@ -0,0 +1,210 @@
+package stream
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/pipes"
+	"github.com/stretchr/testify/require"
+)
+
+func connectViewer(t *testing.T, url string, marshalizerName string, profile logger.Profile) (*http.Response, *pipes.Messenger) {
+	data, err := profile.Marshal()
+	require.Nil(t, err)
+
+	response, err := http.Post(url+"?"+MarshalizerQueryParameter+"="+marshalizerName, "application/json", bytes.NewBuffer(data))
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	return response, pipes.NewMessenger(response.Body, nil)
+}
+
+func readLogLine(t *testing.T, messenger *pipes.Messenger, marshalizerName string) *logger.LogLineWrapper {
+	buff, err := messenger.ReadMessage()
+	require.Nil(t, err)
+
+	marshalizer, err := GetMarshalizer(marshalizerName)
+	require.Nil(t, err)
+
+	wrapper := &logger.LogLineWrapper{}
+	err = marshalizer.Unmarshal(wrapper, buff)
+	require.Nil(t, err)
+
+	return wrapper
+}
+
+func TestNewServer(t *testing.T) {
+	s, err := NewServer(nil, DefaultQueueSize)
+	require.Nil(t, s)
+	require.Equal(t, ErrNilLogOutputHandler, err)
+
+	s, err = NewServer(logger.NewLogOutputSubject(), 0)
+	require.Nil(t, s)
+	require.Equal(t, ErrInvalidQueueSize, err)
+
+	s, err = NewServer(logger.NewLogOutputSubject(), DefaultQueueSize)
+	require.Nil(t, err)
+	require.False(t, s.IsInterfaceNil())
+}
+
+func TestServer_BadRequests(t *testing.T) {
+	s, _ := NewServer(logger.NewLogOutputSubject(), DefaultQueueSize)
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	response, err := http.Get(httpServer.URL)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusMethodNotAllowed, response.StatusCode)
+
+	response, err = http.Post(httpServer.URL+"?marshalizer=xml", "application/json", bytes.NewBufferString(`{"LogLevelPatterns":"*:INFO"}`))
+	require.Nil(t, err)
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response, err = http.Post(httpServer.URL, "application/json", bytes.NewBufferString(`{"LogLevelPatterns":"*:FOO"}`))
+	require.Nil(t, err)
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestServer_StreamsFilteredLogLines(t *testing.T) {
+	for _, marshalizerName := range []string{MarshalizerJSON, MarshalizerGogoProto} {
+		outputSubject := logger.NewLogOutputSubject()
+		s, _ := NewServer(outputSubject, DefaultQueueSize)
+		httpServer := httptest.NewServer(s)
+
+		profile := logger.Profile{LogLevelPatterns: "*:INFO,foo:TRACE", WithLoggerName: true}
+		response, messenger := connectViewer(t, httpServer.URL, marshalizerName, profile)
+
+		fooLog := logger.NewLogger("foo", logger.LogTrace, outputSubject)
+		barLog := logger.NewLogger("bar", logger.LogTrace, outputSubject)
+
+		barLog.Debug("bar-debug-no")
+		fooLog.Trace("foo-trace-yes", "a", 1)
+		barLog.Warn("bar-warn-yes")
+
+		line := readLogLine(t, messenger, marshalizerName)
+		require.Equal(t, "foo", line.LoggerName)
+		require.Equal(t, "foo-trace-yes", line.Message)
+		require.Equal(t, []string{"a", "1"}, line.Args)
+		require.Equal(t, int32(logger.LogTrace), line.LogLevel)
+
+		line = readLogLine(t, messenger, marshalizerName)
+		require.Equal(t, "bar", line.LoggerName)
+		require.Equal(t, "bar-warn-yes", line.Message)
+
+		_ = response.Body.Close()
+		httpServer.Close()
+	}
+}
+
+func TestServer_ViewerPatternsShouldOnlyNarrowTheOutput(t *testing.T) {
+	outputSubject := logger.NewLogOutputSubject()
+	s, _ := NewServer(outputSubject, DefaultQueueSize)
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	profile := logger.Profile{LogLevelPatterns: "*:TRACE"}
+	response, messenger := connectViewer(t, httpServer.URL, MarshalizerJSON, profile)
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	fooLog := logger.NewLogger("foo", logger.LogInfo, outputSubject)
+	fooLog.Debug("below the logger's level")
+	fooLog.Info("at the logger's level")
+
+	line := readLogLine(t, messenger, MarshalizerJSON)
+	require.Equal(t, "at the logger's level", line.Message)
+	require.Equal(t, logger.LogInfo, fooLog.GetLevel())
+}
+
+func TestServer_StripsDisabledElements(t *testing.T) {
+	outputSubject := logger.NewLogOutputSubject()
+	s, _ := NewServer(outputSubject, DefaultQueueSize)
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	profile := logger.Profile{LogLevelPatterns: "*:INFO"}
+	response, messenger := connectViewer(t, httpServer.URL, MarshalizerJSON, profile)
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	fooLog := logger.NewLogger("foo", logger.LogInfo, outputSubject)
+
+	fooLog.Log(&logger.LogLine{
+		LoggerName: "foo",
+		Message:    "message",
+		LogLevel:   logger.LogInfo,
+		Timestamp:  time.Now(),
+	})
+
+	line := readLogLine(t, messenger, MarshalizerJSON)
+	require.Equal(t, "message", line.Message)
+	require.Equal(t, "", line.LoggerName)
+}
+
+func TestServer_SlowViewerShouldNotBlock(t *testing.T) {
+	outputSubject := logger.NewLogOutputSubject()
+	s, _ := NewServer(outputSubject, 1)
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	profile := logger.Profile{LogLevelPatterns: "*:TRACE"}
+	response, messenger := connectViewer(t, httpServer.URL, MarshalizerJSON, profile)
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	fooLog := logger.NewLogger("foo", logger.LogTrace, outputSubject)
+
+	// the viewer does not read anything while the node logs a lot of (large) lines
+	done := make(chan struct{})
+	go func() {
+		largeArg := strings.Repeat("x", 1<<12)
+		for i := 0; i < 2000; i++ {
+			fooLog.Info("message", "arg", largeArg)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "the logging goroutine has been blocked by the slow viewer")
+	}
+
+	foundDroppedNotice := false
+	for i := 0; i < 2000 && !foundDroppedNotice; i++ {
+		line := readLogLine(t, messenger, MarshalizerJSON)
+		foundDroppedNotice = line.Message == droppedLinesMessage
+	}
+	require.True(t, foundDroppedNotice)
+}
+
+func TestServer_ShouldApplyTheViewerCorrelationFilter(t *testing.T) {
+	outputSubject := logger.NewLogOutputSubject()
+	s, _ := NewServer(outputSubject, DefaultQueueSize)
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	profile := logger.Profile{LogLevelPatterns: "*:INFO", WithCorrelation: true, CorrelationFilter: "round>=10"}
+	response, messenger := connectViewer(t, httpServer.URL, MarshalizerJSON, profile)
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	fooLog := logger.NewLogger("foo", logger.LogInfo, outputSubject)
+	for _, round := range []int64{9, 10} {
+		line := &logger.LogLine{Message: "message", LogLevel: logger.LogInfo, Timestamp: time.Now()}
+		line.Correlation.Round = round
+		fooLog.Log(line)
+	}
+
+	line := readLogLine(t, messenger, MarshalizerJSON)
+	require.Equal(t, int64(10), line.Correlation.Round)
+}
@@ -0,0 +1,179 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	logger "github.com/kalyan3104/dme-logger-go"
+	"github.com/kalyan3104/dme-logger-go/check"
+	"github.com/kalyan3104/dme-logger-go/pipes"
+)
+
+var _ io.Writer = (*viewerPart)(nil)
+var _ logger.Formatter = (*viewerPart)(nil)
+
+// viewerPart is the server side of a connected logs viewer. It is registered on the log output subject both as
+// formatter (filtering and marshalizing the log lines according to the viewer's profile) and as writer (queueing
+// the marshalized lines without ever blocking the logging goroutine)
+type viewerPart struct {
+	profile     logger.Profile
+	logLevels   []logger.LogLevel
+	patterns    []string
+	filter      logger.CorrelationFilter
+	marshalizer logger.Marshalizer
+	queue       chan []byte
+	numDropped  uint64
+}
+
+func newViewerPart(profile logger.Profile, marshalizer logger.Marshalizer, queueSize int) (*viewerPart, error) {
+	if check.IfNil(marshalizer) {
+		return nil, logger.ErrNilMarshalizer
+	}
+	if queueSize < 1 {
+		return nil, ErrInvalidQueueSize
+	}
+
+	logLevels, patterns, err := logger.ParseLogLevelAndMatchingString(profile.LogLevelPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	part := &viewerPart{
+		profile:     profile,
+		logLevels:   logLevels,
+		patterns:    patterns,
+		marshalizer: marshalizer,
+		queue:       make(chan []byte, queueSize),
+	}
+	if len(strings.TrimSpace(profile.CorrelationFilter)) > 0 {
+		part.filter, err = logger.NewCorrelationFilter(profile.CorrelationFilter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return part, nil
+}
+
+// Output filters the provided log line against the viewer's log level patterns and correlation filter and marshalizes it,
+// stripping the elements the viewer did not ask for
+func (part *viewerPart) Output(line logger.LogLineHandler) []byte {
+	if check.IfNil(line) {
+		return nil
+	}
+	if !part.shouldOutput(line.GetLoggerName(), logger.LogLevel(line.GetLogLevel())) {
+		return nil
+	}
+	if !check.IfNil(part.filter) && !part.filter.Matches(line.GetCorrelation()) {
+		return nil
+	}
+
+	wrapper := &logger.LogLineWrapper{}
+	wrapper.Message = line.GetMessage()
+	wrapper.LogLevel = line.GetLogLevel()
+	wrapper.Args = line.GetArgs()
+	wrapper.Timestamp = line.GetTimestamp()
+	details, ok := line.(logger.LogLineDetailsHandler)
+	if ok {
+		wrapper.TraceID = details.GetTraceID()
+		wrapper.SpanID = details.GetSpanID()
+		wrapper.Caller = details.GetCaller()
+		wrapper.Stack = details.GetStack()
+	}
+	if part.profile.WithLoggerName {
+		wrapper.LoggerName = line.GetLoggerName()
+	}
+	if part.profile.WithCorrelation {
+		wrapper.Correlation = line.GetCorrelation()
+	}
+
+	buff, err := part.marshalizer.Marshal(wrapper)
+	if err != nil {
+		return nil
+	}
+
+	return buff
+}
+
+// shouldOutput applies the rules in the same manner as logger.SetLogLevel does, from left to right. The lines reaching
+// the viewer have already been filtered by the levels of the node's loggers.
+func (part *viewerPart) shouldOutput(loggerName string, level logger.LogLevel) bool {
+	threshold := defaultViewerLogLevel
+	for i, pattern := range part.patterns {
+		isMatching := pattern == "*" || strings.Contains(loggerName, pattern)
+		if isMatching {
+			threshold = part.logLevels[i]
+		}
+	}
+
+	return level.IsAtLeast(threshold)
+}
+
+// Write queues the marshalized log line. If the queue is full, the line is dropped and accounted for.
+func (part *viewerPart) Write(logLineMarshalized []byte) (int, error) {
+	if len(logLineMarshalized) == 0 {
+		return 0, nil
+	}
+
+	select {
+	case part.queue <- logLineMarshalized:
+	default:
+		atomic.AddUint64(&part.numDropped, 1)
+	}
+
+	return len(logLineMarshalized), nil
+}
+
+// continuouslySend sends the queued log lines to the viewer until the context is done or the viewer goes away
+func (part *viewerPart) continuouslySend(ctx context.Context, messenger *pipes.Messenger, flusher http.Flusher) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case logLineMarshalized := <-part.queue:
+			_, err := messenger.SendMessage(logLineMarshalized)
+			if err != nil {
+				return err
+			}
+
+			err = part.sendDroppedNotice(messenger)
+			if err != nil {
+				return err
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+func (part *viewerPart) sendDroppedNotice(messenger *pipes.Messenger) error {
+	numDropped := atomic.SwapUint64(&part.numDropped, 0)
+	if numDropped == 0 {
+		return nil
+	}
+
+	wrapper := &logger.LogLineWrapper{}
+	wrapper.LoggerName = droppedLinesLoggerName
+	wrapper.Message = droppedLinesMessage
+	wrapper.LogLevel = int32(logger.LogWarning)
+	wrapper.Args = []string{"num", strconv.FormatUint(numDropped, 10)}
+	wrapper.Timestamp = time.Now().UnixNano()
+
+	buff, err := part.marshalizer.Marshal(wrapper)
+	if err != nil {
+		return err
+	}
+
+	_, err = messenger.SendMessage(buff)
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (part *viewerPart) IsInterfaceNil() bool {
+	return part == nil
+}
@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const traceparentVersion = "00"
+const traceIDLength = 32
+const spanIDLength = 16
+const traceFlagsLength = 2
+const invalidTraceparentVersion = "ff"
+
+type traceContextKey struct{}
+
+// TraceParent holds the elements of a W3C trace context traceparent header
+type TraceParent struct {
+	TraceID string
+	SpanID  string
+	Flags   byte
+}
+
+// ParseTraceparent parses a W3C trace context traceparent header ("00-TRACE_ID-SPAN_ID-FLAGS")
+func ParseTraceparent(header string) (TraceParent, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return TraceParent{}, createErrInvalidTraceparent(header, "expected VERSION-TRACE_ID-SPAN_ID-FLAGS")
+	}
+
+	version := parts[0]
+	if !isLowerHex(version, 2) || version == invalidTraceparentVersion {
+		return TraceParent{}, createErrInvalidTraceparent(header, "invalid version")
+	}
+	// the future versions may append fields, the version 00 can not
+	if version == traceparentVersion && len(parts) != 4 {
+		return TraceParent{}, createErrInvalidTraceparent(header, "unexpected fields for version 00")
+	}
+	if !IsValidTraceID(parts[1]) {
+		return TraceParent{}, createErrInvalidTraceparent(header, "invalid trace ID")
+	}
+	if !IsValidSpanID(parts[2]) {
+		return TraceParent{}, createErrInvalidTraceparent(header, "invalid span ID")
+	}
+	if !isLowerHex(parts[3], traceFlagsLength) {
+		return TraceParent{}, createErrInvalidTraceparent(header, "invalid flags")
+	}
+
+	flags, _ := hex.DecodeString(parts[3])
+
+	return TraceParent{
+		TraceID: parts[1],
+		SpanID:  parts[2],
+		Flags:   flags[0],
+	}, nil
+}
+
+// String formats the traceparent header
+func (tp TraceParent) String() string {
+	return fmt.Sprintf("%s-%s-%s-%02x", traceparentVersion, tp.TraceID, tp.SpanID, tp.Flags)
+}
+
+// IsSampled returns true if the sampled flag is set
+func (tp TraceParent) IsSampled() bool {
+	return tp.Flags&0x01 != 0
+}
+
+// IsValidTraceID returns true if the provided string is a valid W3C trace ID: 32 lowercase hex characters, not all zero
+func IsValidTraceID(traceID string) bool {
+	return isLowerHex(traceID, traceIDLength) && strings.Trim(traceID, "0") != ""
+}
+
+// IsValidSpanID returns true if the provided string is a valid W3C span ID: 16 lowercase hex characters, not all zero
+func IsValidSpanID(spanID string) bool {
+	return isLowerHex(spanID, spanIDLength) && strings.Trim(spanID, "0") != ""
+}
+
+func isLowerHex(str string, length int) bool {
+	if len(str) != length {
+		return false
+	}
+
+	for _, c := range str {
+		isDigit := c >= '0' && c <= '9'
+		isLowerHexLetter := c >= 'a' && c <= 'f'
+		if !isDigit && !isLowerHexLetter {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ContextWithTrace returns a copy of the provided context carrying the trace and span IDs, to be used with
+// TraceLogger.WithContext
+func ContextWithTrace(ctx context.Context, traceID string, spanID string) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, TraceParent{TraceID: traceID, SpanID: spanID})
+}
+
+// TraceFromContext returns the trace and span IDs carried by the provided context, if any
+func TraceFromContext(ctx context.Context) (traceID string, spanID string, ok bool) {
+	if ctx == nil {
+		return "", "", false
+	}
+
+	tp, ok := ctx.Value(traceContextKey{}).(TraceParent)
+	if !ok {
+		return "", "", false
+	}
+
+	return tp.TraceID, tp.SpanID, true
+}
+
+// LoggerWithContext returns a logger stamping the trace and span IDs carried by the provided context on the log lines
+// of the provided logger. The provided logger itself is returned if it is not a TraceLogger.
+func LoggerWithContext(log Logger, ctx context.Context) Logger {
+	traceLogger, ok := log.(TraceLogger)
+	if !ok {
+		return log
+	}
+
+	return traceLogger.WithContext(ctx)
+}
+
+func formatTraceElements(traceID string, spanID string) string {
+	if len(traceID) == 0 && len(spanID) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("[trace=%s span=%s]", traceID, spanID)
+}
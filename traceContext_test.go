@@ -0,0 +1,212 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kalyan3104/dme-logger-go/proto"
+	"github.com/stretchr/testify/require"
+)
+
+const testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+const testSpanID = "00f067aa0ba902b7"
+
+type lineCapture struct {
+	lines []*LogLine
+}
+
+func (lc *lineCapture) Output(line *LogLine) {
+	lc.lines = append(lc.lines, line)
+}
+
+func (lc *lineCapture) AddObserver(_ io.Writer, _ Formatter) error {
+	return nil
+}
+
+func (lc *lineCapture) RemoveObserver(_ io.Writer) error {
+	return nil
+}
+
+func (lc *lineCapture) ClearObservers() {
+}
+
+func (lc *lineCapture) IsInterfaceNil() bool {
+	return lc == nil
+}
+
+func TestParseTraceparent(t *testing.T) {
+	t.Parallel()
+
+	tp, err := ParseTraceparent("00-" + testTraceID + "-" + testSpanID + "-01")
+	require.Nil(t, err)
+	require.Equal(t, testTraceID, tp.TraceID)
+	require.Equal(t, testSpanID, tp.SpanID)
+	require.Equal(t, byte(1), tp.Flags)
+	require.True(t, tp.IsSampled())
+	require.Equal(t, "00-"+testTraceID+"-"+testSpanID+"-01", tp.String())
+
+	// future versions may carry more fields
+	tp, err = ParseTraceparent("01-" + testTraceID + "-" + testSpanID + "-00-extra")
+	require.Nil(t, err)
+	require.False(t, tp.IsSampled())
+	require.Equal(t, "00-"+testTraceID+"-"+testSpanID+"-00", tp.String())
+
+	invalidHeaders := []string{
+		"",
+		"00-" + testTraceID + "-" + testSpanID,
+		"00-" + testTraceID + "-" + testSpanID + "-01-extra",
+		"ff-" + testTraceID + "-" + testSpanID + "-01",
+		"0x-" + testTraceID + "-" + testSpanID + "-01",
+		"00-" + strings.Repeat("0", 32) + "-" + testSpanID + "-01",
+		"00-" + testTraceID + "-" + strings.Repeat("0", 16) + "-01",
+		"00-" + strings.ToUpper(testTraceID) + "-" + testSpanID + "-01",
+		"00-" + testTraceID[1:] + "-" + testSpanID + "-01",
+		"00-" + testTraceID + "-" + testSpanID + "-1",
+	}
+	for _, header := range invalidHeaders {
+		_, err = ParseTraceparent(header)
+		require.True(t, errors.Is(err, ErrInvalidTraceparent), header)
+	}
+}
+
+func TestTraceFromContext(t *testing.T) {
+	t.Parallel()
+
+	_, _, ok := TraceFromContext(context.Background())
+	require.False(t, ok)
+
+	ctx := ContextWithTrace(context.Background(), testTraceID, testSpanID)
+	traceID, spanID, ok := TraceFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, testTraceID, traceID)
+	require.Equal(t, testSpanID, spanID)
+}
+
+func TestLogger_WithTraceShouldStampTheLogLines(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	log := NewLogger("test", LogDebug, capture)
+	tracedLog := log.WithTrace(testTraceID, testSpanID)
+
+	tracedLog.Trace("filtered out")
+	tracedLog.Info("traced")
+	log.Info("not traced")
+	tracedLog.Log(&LogLine{Message: "logged"})
+	tracedLog.Log(&LogLine{Message: "own trace", TraceID: "trace", SpanID: "span"})
+
+	require.Equal(t, 4, len(capture.lines))
+	require.Equal(t, testTraceID, capture.lines[0].TraceID)
+	require.Equal(t, testSpanID, capture.lines[0].SpanID)
+	require.Equal(t, "", capture.lines[1].TraceID)
+	require.Equal(t, testTraceID, capture.lines[2].TraceID)
+	require.Equal(t, "trace", capture.lines[3].TraceID)
+
+	// the traced logger shares the level of its parent
+	log.SetLevel(LogWarning)
+	tracedLog.Info("filtered out")
+	require.Equal(t, 4, len(capture.lines))
+}
+
+func TestLogger_WithContext(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	log := NewLogger("test", LogDebug, capture)
+	require.True(t, log.WithContext(context.Background()) == Logger(log))
+
+	ctx := ContextWithTrace(context.Background(), testTraceID, testSpanID)
+	log.WithContext(ctx).Warn("traced")
+	require.Equal(t, 1, len(capture.lines))
+	require.Equal(t, testTraceID, capture.lines[0].TraceID)
+	require.Equal(t, testSpanID, capture.lines[0].SpanID)
+}
+
+type loggerOnly struct {
+	Logger
+}
+
+func TestLoggerWithContext(t *testing.T) {
+	t.Parallel()
+
+	capture := &lineCapture{}
+	log := NewLogger("test", LogDebug, capture)
+	var _ TraceLogger = log
+
+	ctx := ContextWithTrace(context.Background(), testTraceID, testSpanID)
+	LoggerWithContext(log, ctx).Warn("traced")
+	require.Equal(t, testTraceID, capture.lines[0].TraceID)
+
+	other := &loggerOnly{Logger: log}
+	require.True(t, LoggerWithContext(other, ctx) == Logger(other))
+}
+
+func TestPlainFormatter_ShouldRenderTraceElements(t *testing.T) {
+	t.Parallel()
+
+	line := &LogLineWrapper{}
+	line.Message = "message"
+	line.TraceID = testTraceID
+	line.SpanID = testSpanID
+
+	output := string((&PlainFormatter{}).Output(line))
+	require.Contains(t, output, "[trace="+testTraceID+" span="+testSpanID+"]")
+
+	output = string((&ConsoleFormatter{}).Output(line))
+	require.Contains(t, output, "[trace="+testTraceID+" span="+testSpanID+"]")
+
+	line.TraceID = ""
+	line.SpanID = ""
+	output = string((&PlainFormatter{}).Output(line))
+	require.NotContains(t, output, "trace=")
+}
+
+// basicLogLine implements only LogLineHandler, as the log lines of the other implementations might
+type basicLogLine struct {
+	message string
+}
+
+func (line *basicLogLine) GetLoggerName() string {
+	return ""
+}
+
+func (line *basicLogLine) GetCorrelation() proto.LogCorrelationMessage {
+	return proto.LogCorrelationMessage{}
+}
+
+func (line *basicLogLine) GetMessage() string {
+	return line.message
+}
+
+func (line *basicLogLine) GetLogLevel() int32 {
+	return int32(LogInfo)
+}
+
+func (line *basicLogLine) GetArgs() []string {
+	return nil
+}
+
+func (line *basicLogLine) GetTimestamp() int64 {
+	return 0
+}
+
+func (line *basicLogLine) IsInterfaceNil() bool {
+	return line == nil
+}
+
+func TestFormatters_LinesWithoutDetailsShouldBeFormatted(t *testing.T) {
+	t.Parallel()
+
+	line := &basicLogLine{message: "message"}
+
+	output := string((&PlainFormatter{}).Output(line))
+	require.Contains(t, output, "message")
+	require.NotContains(t, output, "trace=")
+
+	output = string((&ConsoleFormatter{}).Output(line))
+	require.Contains(t, output, "message")
+	require.NotContains(t, output, "trace=")
+}